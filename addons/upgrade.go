@@ -6,11 +6,18 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/costs"
 	"github.com/Scalingo/cli/io"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func Upgrade(ctx context.Context, app, addonID, plan string) error {
+type UpgradeOpts struct {
+	// DryRun only prints the monthly cost of the addon before and after the
+	// upgrade, without upgrading it
+	DryRun bool
+}
+
+func Upgrade(ctx context.Context, app, addonID, plan string, opts UpgradeOpts) error {
 	if app == "" {
 		return errgo.New("no app defined")
 	} else if addonID == "" {
@@ -24,7 +31,7 @@ func Upgrade(ctx context.Context, app, addonID, plan string) error {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
 
-	addon, err := checkAddonExist(ctx, c, app, addonID)
+	addon, err := findAddonToUpgrade(ctx, c, app, addonID)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
@@ -34,6 +41,10 @@ func Upgrade(ctx context.Context, app, addonID, plan string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
+	if opts.DryRun {
+		return upgradeDryRun(ctx, c, app, addon, planID, plan)
+	}
+
 	params, err := c.AddonUpgrade(ctx, app, addon.ID, scalingo.AddonUpgradeParams{
 		PlanID: planID,
 	})
@@ -50,3 +61,66 @@ func Upgrade(ctx context.Context, app, addonID, plan string) error {
 	}
 	return nil
 }
+
+// findAddonToUpgrade returns the addon with the ID addonID. The ID of an addon
+// differs between apps, the ID of its provider (e.g. postgresql) is also
+// accepted if the app has a single addon of this provider so that the same
+// upgrade can be run against several apps.
+func findAddonToUpgrade(ctx context.Context, c *scalingo.Client, app, addonID string) (*scalingo.Addon, error) {
+	addons, err := c.AddonsList(ctx, app)
+	if err != nil {
+		return nil, errgo.Mask(err, errgo.Any)
+	}
+
+	var providerAddons []*scalingo.Addon
+	for _, addon := range addons {
+		if addon.ID == addonID {
+			return addon, nil
+		}
+		if addon.AddonProvider != nil && addon.AddonProvider.ID == addonID {
+			providerAddons = append(providerAddons, addon)
+		}
+	}
+	if len(providerAddons) == 1 {
+		return providerAddons[0], nil
+	}
+	if len(providerAddons) > 1 {
+		return nil, errgo.Newf("app %s has %d %s addons, use the ID of the addon to upgrade", app, len(providerAddons), addonID)
+	}
+	return checkAddonExist(ctx, c, app, addonID)
+}
+
+// upgradeDryRun prints the monthly cost of the addon and of the application
+// before and after the upgrade
+func upgradeDryRun(ctx context.Context, c *scalingo.Client, app string, addon *scalingo.Addon, planID, plan string) error {
+	if addon.Plan == nil || addon.AddonProvider == nil {
+		return errgo.Newf("the plan of the addon %s is unknown", addon.ID)
+	}
+	io.Statusf("Dry run, the addon %s is not upgraded\n", addon.ID)
+	io.Infof("Plan: %s → %s\n", addon.Plan.Name, plan)
+
+	prices, err := costs.NewPrices(ctx, c)
+	if err != nil {
+		return errgo.Notef(err, "fail to estimate the monthly cost")
+	}
+	// A plan with an unknown price is not an error, the upgrade itself is
+	// still described
+	before, err := prices.Plan(ctx, addon.AddonProvider.ID, addon.Plan.ID)
+	if err != nil {
+		io.Warningf("The monthly cost cannot be estimated: %v\n", err)
+		return nil
+	}
+	after, err := prices.Plan(ctx, addon.AddonProvider.ID, planID)
+	if err != nil {
+		io.Warningf("The monthly cost cannot be estimated: %v\n", err)
+		return nil
+	}
+	io.Infof("Monthly cost of the addon: %s\n", costs.FormatPriceChange(before, after))
+
+	appCosts, err := costs.Estimate(ctx, c, prices, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to estimate the monthly cost of the application")
+	}
+	io.Infof("Monthly cost of the application: %s\n", costs.FormatPriceChange(appCosts.Total(), appCosts.Total()-before+after))
+	return nil
+}
@@ -0,0 +1,353 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// CloneOpts are the parameters of Clone
+type CloneOpts struct {
+	Source string
+	Target string
+	// TargetRegion is the region of the new application, the current region by
+	// default
+	TargetRegion      string
+	WithAddons        bool
+	WithCollaborators bool
+	WithEnv           bool
+}
+
+// Clone creates the application Target with the configuration of Source:
+// stack and settings, and optionally its addons plans, environment and
+// collaborators. The formation is printed as the scale command to run after
+// the first deployment of Target. The data and the certificates are never
+// copied. The elements which could not be copied are listed at the end, they do
+// not stop the cloning.
+func Clone(ctx context.Context, opts CloneOpts) error {
+	sourceRegion := config.C.ScalingoRegion
+	targetRegion := opts.TargetRegion
+	if targetRegion == "" {
+		targetRegion = sourceRegion
+	}
+
+	source, err := config.ScalingoClientForRegion(ctx, sourceRegion)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client for the region %s", sourceRegion)
+	}
+	target, err := config.ScalingoClientForRegion(ctx, targetRegion)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client for the region %s", targetRegion)
+	}
+
+	sourceApp, err := source.AppsShow(ctx, opts.Source)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the source application")
+	}
+
+	notCopied := []string{}
+	stackID, err := targetStackID(ctx, source, target, sourceApp.StackID)
+	if err != nil {
+		notCopied = append(notCopied, fmt.Sprintf("stack: %v, the default stack is used", err))
+	}
+
+	app, err := target.AppsCreate(ctx, scalingo.AppsCreateOpts{Name: opts.Target, StackID: stackID})
+	if err != nil {
+		return errgo.Notef(err, "fail to create the application")
+	}
+	io.Statusf("App %s created in the region %s from %s\n", app.Name, targetRegion, sourceApp.Name)
+
+	notCopied = append(notCopied, copySettings(ctx, target, sourceApp, app.Name)...)
+	scaleCommand, err := FormationScaleCommand(ctx, source, sourceApp.Name, app.Name, targetRegion)
+	if err != nil {
+		notCopied = append(notCopied, fmt.Sprintf("formation: %v", err))
+	}
+	if opts.WithAddons {
+		notCopied = append(notCopied, CopyAddons(ctx, source, target, sourceApp.Name, app.Name)...)
+	}
+	if opts.WithEnv {
+		notCopied = append(notCopied, CopyEnvironment(ctx, source, target, sourceApp.Name, app.Name)...)
+	}
+	if opts.WithCollaborators {
+		notCopied = append(notCopied, CopyCollaborators(ctx, source, target, sourceApp.Name, app.Name)...)
+	}
+	notCopied = append(notCopied, notCopiedData(ctx, source, sourceApp.Name, opts.WithAddons)...)
+
+	if len(notCopied) > 0 {
+		io.Warning("The following elements of", sourceApp.Name, "were not copied:")
+		for _, element := range notCopied {
+			fmt.Println(io.Indent("- "+element, 7))
+		}
+	}
+	if scaleCommand == "" {
+		io.Infof("Deploy your code to %s to start it.\n", app.Name)
+		return nil
+	}
+	io.Infof("Deploy your code to %s, then apply the formation of %s with:\n", app.Name, sourceApp.Name)
+	fmt.Println(io.Indent(scaleCommand, 7))
+	return nil
+}
+
+// targetStackID returns the ID of the stack of the target region with the same
+// name as the stack sourceStackID. The stacks IDs differ between regions.
+func targetStackID(ctx context.Context, source, target *scalingo.Client, sourceStackID string) (string, error) {
+	if sourceStackID == "" {
+		return "", nil
+	}
+	sourceStacks, err := source.StacksList(ctx)
+	if err != nil {
+		return "", errgo.Notef(err, "fail to list the stacks")
+	}
+	stackName := ""
+	for _, stack := range sourceStacks {
+		if stack.ID == sourceStackID {
+			stackName = stack.Name
+		}
+	}
+	if stackName == "" {
+		return "", errgo.Newf("unknown stack %s", sourceStackID)
+	}
+
+	targetStacks, err := target.StacksList(ctx)
+	if err != nil {
+		return "", errgo.Notef(err, "fail to list the stacks of the target region")
+	}
+	for _, stack := range targetStacks {
+		if stack.Name == stackName {
+			return stack.ID, nil
+		}
+	}
+	return "", errgo.Newf("the stack %s is not available in the target region", stackName)
+}
+
+func copySettings(ctx context.Context, target *scalingo.Client, sourceApp *scalingo.App, app string) []string {
+	notCopied := []string{}
+	if sourceApp.ForceHTTPS {
+		_, err := target.AppsForceHTTPS(ctx, app, true)
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("force HTTPS setting: %v", err))
+		}
+	}
+	if sourceApp.StickySession {
+		_, err := target.AppsStickySession(ctx, app, true)
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("sticky session setting: %v", err))
+		}
+	}
+	if sourceApp.RouterLogs {
+		_, err := target.AppsRouterLogs(ctx, app, true)
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("router logs setting: %v", err))
+		}
+	}
+	return notCopied
+}
+
+// CopyAddons provisions on app the addons of source with the same plans. The
+// plans are looked up by name since their IDs differ between regions. The data
+// of the addons is not copied.
+func CopyAddons(ctx context.Context, sourceClient, targetClient *scalingo.Client, source, app string) []string {
+	addons, err := sourceClient.AddonsList(ctx, source)
+	if err != nil {
+		return []string{fmt.Sprintf("addons: %v", err)}
+	}
+
+	notCopied := []string{}
+	for _, addon := range addons {
+		if addon.AddonProvider == nil || addon.Plan == nil {
+			continue
+		}
+		planID, err := targetPlanID(ctx, targetClient, addon.AddonProvider.ID, addon.Plan.Name)
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("addon %s (%s): %v", addon.AddonProvider.Name, addon.Plan.Name, err))
+			continue
+		}
+		_, err = targetClient.AddonProvision(ctx, app, scalingo.AddonProvisionParams{
+			AddonProviderID: addon.AddonProvider.ID,
+			PlanID:          planID,
+		})
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("addon %s (%s): %v", addon.AddonProvider.Name, addon.Plan.Name, err))
+			continue
+		}
+		io.Statusf("Addon %s provisioned with the plan %s\n", addon.AddonProvider.Name, addon.Plan.Name)
+	}
+	return notCopied
+}
+
+func targetPlanID(ctx context.Context, c *scalingo.Client, providerID, planName string) (string, error) {
+	plans, err := c.AddonProviderPlansList(ctx, providerID)
+	if err != nil {
+		return "", errgo.Notef(err, "fail to list the plans")
+	}
+	for _, plan := range plans {
+		if plan.Name == planName && !plan.Disabled {
+			return plan.ID, nil
+		}
+	}
+	return "", errgo.New("plan not available")
+}
+
+// CopyEnvironment copies the environment variables of source. The ones
+// already defined on app, like the ones of its addons, are kept. The ones
+// generated by the addons of source are never copied: they give access to the
+// addons of source, not to the ones of app.
+func CopyEnvironment(ctx context.Context, sourceClient, targetClient *scalingo.Client, source, app string) []string {
+	sourceVariables, err := sourceClient.VariablesListWithoutAlias(ctx, source)
+	if err != nil {
+		return []string{fmt.Sprintf("environment: %v", err)}
+	}
+	sourceAddons, err := sourceClient.AddonsList(ctx, source)
+	if err != nil {
+		return []string{fmt.Sprintf("environment: %v", err)}
+	}
+	appVariables, err := targetClient.VariablesListWithoutAlias(ctx, app)
+	if err != nil {
+		return []string{fmt.Sprintf("environment: %v", err)}
+	}
+
+	notCopied := []string{}
+	variables := scalingo.Variables{}
+	for _, variable := range sourceVariables {
+		if _, ok := appVariables.Contains(variable.Name); ok {
+			continue
+		}
+		if addon := addonOfVariable(sourceAddons, variable.Name); addon != "" {
+			notCopied = append(notCopied, fmt.Sprintf("environment variable %s, generated by the addon %s", variable.Name, addon))
+			continue
+		}
+		variables = append(variables, &scalingo.Variable{Name: variable.Name, Value: variable.Value})
+	}
+	if len(variables) == 0 {
+		return notCopied
+	}
+
+	_, _, err = targetClient.VariableMultipleSet(ctx, app, variables)
+	if err != nil {
+		return append(notCopied, fmt.Sprintf("environment: %v", err))
+	}
+	io.Statusf("%d environment variables copied\n", len(variables))
+	return notCopied
+}
+
+// addonOfVariable returns the name of the addon which generated the environment
+// variable, or an empty string if it is not generated by an addon. The
+// variables of an addon are prefixed by the ID of its provider, without the
+// trailing DB for some of them: SCALINGO_POSTGRESQL_URL for
+// scalingo-postgresql, SCALINGO_MONGO_URL for scalingo-mongodb.
+func addonOfVariable(addons []*scalingo.Addon, variable string) string {
+	for _, addon := range addons {
+		if addon.AddonProvider == nil {
+			continue
+		}
+		prefix := strings.ToUpper(strings.ReplaceAll(addon.AddonProvider.ID, "-", "_"))
+		if strings.HasPrefix(variable, prefix+"_") || strings.HasPrefix(variable, strings.TrimSuffix(prefix, "DB")+"_") {
+			return addon.AddonProvider.Name
+		}
+	}
+	return ""
+}
+
+// CopyCollaborators invites on app the collaborators of source
+func CopyCollaborators(ctx context.Context, sourceClient, targetClient *scalingo.Client, source, app string) []string {
+	collaborators, err := sourceClient.CollaboratorsList(ctx, source)
+	if err != nil {
+		return []string{fmt.Sprintf("collaborators: %v", err)}
+	}
+
+	notCopied := []string{}
+	for _, collaborator := range collaborators {
+		if collaborator.Status == scalingo.CollaboratorStatusDeleted {
+			continue
+		}
+		_, err := targetClient.CollaboratorAdd(ctx, app, collaborator.Email)
+		if err != nil {
+			notCopied = append(notCopied, fmt.Sprintf("collaborator %s: %v", collaborator.Email, err))
+			continue
+		}
+		io.Statusf("%s invited as collaborator\n", collaborator.Email)
+	}
+	return notCopied
+}
+
+// CopyFormation scales app like source. An application cannot be scaled before
+// its first deployment, app must have been deployed.
+func CopyFormation(ctx context.Context, sourceClient, targetClient *scalingo.Client, source, app string) []string {
+	containerTypes, err := sourceClient.AppsContainerTypes(ctx, source)
+	if err != nil {
+		return []string{fmt.Sprintf("formation: %v", err)}
+	}
+	if len(containerTypes) == 0 {
+		return nil
+	}
+
+	containers := make([]scalingo.ContainerType, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		containers = append(containers, scalingo.ContainerType{
+			Name:   containerType.Name,
+			Amount: containerType.Amount,
+			Size:   containerType.Size,
+		})
+	}
+	res, err := targetClient.AppsScale(ctx, app, &scalingo.AppsScaleParams{Containers: containers})
+	if err != nil {
+		return []string{fmt.Sprintf("formation: %v", err)}
+	}
+	res.Body.Close()
+	io.Status("Formation copied")
+	return nil
+}
+
+// FormationScaleCommand returns the scale command which gives app the
+// formation of source. An application cannot be scaled before its first
+// deployment, so the command is run once app is deployed.
+func FormationScaleCommand(ctx context.Context, c *scalingo.Client, source, app, region string) (string, error) {
+	containerTypes, err := c.AppsContainerTypes(ctx, source)
+	if err != nil {
+		return "", errgo.Notef(err, "fail to get the formation")
+	}
+	if len(containerTypes) == 0 {
+		return "", nil
+	}
+
+	instructions := make([]string, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		instructions = append(instructions, fmt.Sprintf("%s:%d:%s", containerType.Name, containerType.Amount, containerType.Size))
+	}
+	return fmt.Sprintf("scalingo --region %s --app %s scale %s", region, app, strings.Join(instructions, " ")), nil
+}
+
+// notCopiedData lists the elements of source which are never copied: the data
+// of the addons and the domains with their certificates
+func notCopiedData(ctx context.Context, c *scalingo.Client, source string, withAddons bool) []string {
+	notCopied := []string{}
+	if withAddons {
+		addons, err := c.AddonsList(ctx, source)
+		if err == nil {
+			for _, addon := range addons {
+				if addon.AddonProvider == nil {
+					continue
+				}
+				notCopied = append(notCopied, fmt.Sprintf("data of the addon %s", addon.AddonProvider.Name))
+			}
+		}
+	}
+
+	domains, err := c.DomainsList(ctx, source)
+	if err == nil {
+		for _, domain := range domains {
+			element := fmt.Sprintf("domain %s", domain.Name)
+			if domain.SSL && !domain.LetsEncrypt {
+				element += " and its certificate"
+			}
+			notCopied = append(notCopied, element)
+		}
+	}
+	return notCopied
+}
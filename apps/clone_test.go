@@ -0,0 +1,51 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestAddonOfVariable(t *testing.T) {
+	addons := []*scalingo.Addon{
+		{AddonProvider: &scalingo.AddonProvider{ID: "scalingo-postgresql", Name: "PostgreSQL"}},
+		{AddonProvider: &scalingo.AddonProvider{ID: "scalingo-mongodb", Name: "MongoDB"}},
+		{AddonProvider: &scalingo.AddonProvider{ID: "scalingo-redis", Name: "Redis"}},
+		{},
+	}
+
+	tests := map[string]struct {
+		variable      string
+		expectedAddon string
+	}{
+		"with the URL of an addon": {
+			variable:      "SCALINGO_POSTGRESQL_URL",
+			expectedAddon: "PostgreSQL",
+		},
+		"with another variable of an addon": {
+			variable:      "SCALINGO_REDIS_PASSWORD",
+			expectedAddon: "Redis",
+		},
+		"with a provider ending with DB": {
+			variable:      "SCALINGO_MONGO_URL",
+			expectedAddon: "MongoDB",
+		},
+		"with a variable of the application": {
+			variable: "DATABASE_URL",
+		},
+		"with a variable sharing the beginning of the provider ID": {
+			variable: "SCALINGO_POSTGRESQLURL",
+		},
+		"with a variable of a provider the application does not use": {
+			variable: "SCALINGO_MYSQL_URL",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedAddon, addonOfVariable(addons, test.variable))
+		})
+	}
+}
@@ -10,16 +10,15 @@ import (
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func Events(ctx context.Context, app string, paginationOpts scalingo.PaginationOpts) error {
+func Events(ctx context.Context, app string, opts events.TimelineOpts) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
 
-	appEvents, pagination, err := c.EventsList(ctx, app, paginationOpts)
-	if err != nil {
-		return errgo.Mask(err)
+	fetch := func(ctx context.Context, paginationOpts scalingo.PaginationOpts) (scalingo.Events, scalingo.PaginationMeta, error) {
+		return c.EventsList(ctx, app, paginationOpts)
 	}
-
-	return events.DisplayTimeline(appEvents, pagination, events.DisplayTimelineOpts{})
+	opts.DisplayAppName = false
+	return events.Timeline(ctx, fetch, opts)
 }
@@ -4,46 +4,196 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func List(ctx context.Context) error {
-	c, err := config.ScalingoClient(ctx)
-	if err != nil {
-		return errgo.Notef(err, "fail to get Scalingo client")
+// ListFilter selects the applications displayed by List. Empty fields match
+// all the applications.
+type ListFilter struct {
+	// Owner is the email or the username of the owner of the application
+	Owner string
+	// Stack is the name of the stack of the application
+	Stack  string
+	Status string
+}
+
+// Match returns true if the application, running on the stack stackName,
+// matches the filter
+func (f ListFilter) Match(app *scalingo.App, stackName string) bool {
+	if f.Owner != "" && !strings.EqualFold(f.Owner, app.Owner.Email) && !strings.EqualFold(f.Owner, app.Owner.Username) {
+		return false
 	}
+	if f.Stack != "" && f.Stack != stackName {
+		return false
+	}
+	if f.Status != "" && f.Status != string(app.Status) {
+		return false
+	}
+	return true
+}
 
-	apps, err := c.AppsList(ctx)
+type ListOpts struct {
+	// AllRegions lists the applications of all the regions instead of the
+	// current one
+	AllRegions bool
+	Filter     ListFilter
+}
+
+type regionApp struct {
+	Region string
+	*scalingo.App
+}
+
+func List(ctx context.Context, opts ListOpts) error {
+	regions := []string{config.C.ScalingoRegion}
+	if opts.AllRegions {
+		regionsCache, err := config.EnsureRegionsCache(ctx, config.C, config.GetRegionOpts{})
+		if err != nil {
+			return errgo.Notef(err, "fail to list the regions")
+		}
+		regions = make([]string, 0, len(regionsCache.Regions))
+		for _, region := range regionsCache.Regions {
+			regions = append(regions, region.Name)
+		}
+	}
+
+	apps, err := listRegionsApps(ctx, regions, opts.Filter)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
 
 	if len(apps) == 0 {
+		if opts.Filter != (ListFilter{}) {
+			io.Status("No app matches the filters")
+			return nil
+		}
 		fmt.Println(io.Indent("\nYou haven't created any app yet, create your first application using:\n→ scalingo create <app_name>\n", 2))
 		return nil
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
-	t.SetHeader([]string{"Name", "Role", "Status"})
-
 	currentUser, err := config.C.CurrentUser()
 	if err != nil {
 		return errgo.Notef(err, "fail to get current user")
 	}
 
+	t := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Name", "Role", "Status"}
+	if opts.AllRegions {
+		header = append([]string{"Region"}, header...)
+	}
+	t.SetHeader(header)
+
 	for _, app := range apps {
+		role := "collaborator"
 		if app.Owner.Email == currentUser.Email {
-			t.Append([]string{app.Name, "owner", string(app.Status)})
-		} else {
-			t.Append([]string{app.Name, "collaborator", string(app.Status)})
+			role = "owner"
 		}
+		row := []string{app.Name, role, string(app.Status)}
+		if opts.AllRegions {
+			row = append([]string{app.Region}, row...)
+		}
+		t.Append(row)
 	}
 	t.Render()
 
 	return nil
 }
+
+// listRegionsApps fetches concurrently the applications of the regions. A
+// region which cannot be reached is skipped with a warning, an error is only
+// returned if all of them failed.
+func listRegionsApps(ctx context.Context, regions []string, filter ListFilter) ([]regionApp, error) {
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		apps     []regionApp
+		failures int
+		lastErr  error
+	)
+	for _, region := range regions {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			regionApps, err := listRegionApps(ctx, region, filter)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				failures++
+				lastErr = err
+				if len(regions) == 1 {
+					return
+				}
+				if utils.IsRegionDisabledError(err) {
+					io.Warningf("The region %s is disabled, its apps are not listed.\n", region)
+				} else {
+					io.Warningf("Fail to list the apps of the region %s: %v\n", region, err)
+				}
+				return
+			}
+			apps = append(apps, regionApps...)
+		}(region)
+	}
+	wg.Wait()
+
+	if failures == len(regions) {
+		return nil, lastErr
+	}
+
+	// The apps of a region are kept in the order of the API
+	sort.SliceStable(apps, func(i, j int) bool {
+		return apps[i].Region < apps[j].Region
+	})
+	return apps, nil
+}
+
+func listRegionApps(ctx context.Context, region string, filter ListFilter) ([]regionApp, error) {
+	// The client of the current region honors the API URL overrides
+	var c *scalingo.Client
+	var err error
+	if region == config.C.ScalingoRegion {
+		c, err = config.ScalingoClient(ctx)
+	} else {
+		c, err = config.ScalingoClientForRegion(ctx, region)
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	apps, err := c.AppsList(ctx)
+	if err != nil {
+		return nil, errgo.Mask(err, errgo.Any)
+	}
+
+	// The stacks IDs differ between regions, they are only fetched to filter on
+	// the stack name
+	stackNames := map[string]string{}
+	if filter.Stack != "" {
+		stacks, err := c.StacksList(ctx)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to list the stacks")
+		}
+		for _, stack := range stacks {
+			stackNames[stack.ID] = stack.Name
+		}
+	}
+
+	regionApps := []regionApp{}
+	for _, app := range apps {
+		if !filter.Match(app, stackNames[app.StackID]) {
+			continue
+		}
+		regionApps = append(regionApps, regionApp{Region: region, App: app})
+	}
+	return regionApps, nil
+}
@@ -0,0 +1,57 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestListFilter_Match(t *testing.T) {
+	app := &scalingo.App{
+		Name:   "my-app",
+		Owner:  scalingo.Owner{Username: "john", Email: "john@example.com"},
+		Status: scalingo.AppStatusRunning,
+	}
+
+	tests := map[string]struct {
+		filter        ListFilter
+		stackName     string
+		expectedMatch bool
+	}{
+		"an empty filter matches all the apps": {
+			expectedMatch: true,
+		},
+		"the owner matches on the email": {
+			filter:        ListFilter{Owner: "John@Example.com"},
+			expectedMatch: true,
+		},
+		"the owner matches on the username": {
+			filter:        ListFilter{Owner: "john"},
+			expectedMatch: true,
+		},
+		"another owner does not match": {
+			filter: ListFilter{Owner: "jane@example.com"},
+		},
+		"the stack matches on its name": {
+			filter:        ListFilter{Stack: "scalingo-22"},
+			stackName:     "scalingo-22",
+			expectedMatch: true,
+		},
+		"another stack does not match": {
+			filter:    ListFilter{Stack: "scalingo-20"},
+			stackName: "scalingo-22",
+		},
+		"all the criteria must match": {
+			filter:    ListFilter{Owner: "john", Status: "stopped"},
+			stackName: "scalingo-22",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expectedMatch, test.filter.Match(app, test.stackName))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+const (
+	oneOffContainerType = "one-off"
+	oneOffWaitInterval  = 5 * time.Second
+)
+
+var (
+	detachedOneOffsFile = filepath.Join(config.C.ConfigDir, "one_offs.json")
+)
+
+// detachedOneOff is a one-off started with 'run --detached'. Its attach URL is
+// kept locally to get its exit code once it is done.
+type detachedOneOff struct {
+	App       string    `json:"app"`
+	Region    string    `json:"region"`
+	Label     string    `json:"label"`
+	AttachURL string    `json:"attach_url"`
+	Command   string    `json:"command"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OneOffs lists the one-off containers of the application running currently
+func OneOffs(ctx context.Context, app string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	containers, err := c.AppsContainersPs(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the application containers")
+	}
+
+	oneOffs := []scalingo.Container{}
+	for _, container := range containers {
+		if container.Type == oneOffContainerType {
+			oneOffs = append(oneOffs, container)
+		}
+	}
+	if len(oneOffs) == 0 {
+		io.Status("No one-off container is running")
+		return nil
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Name", "Status", "Command", "Size", "Age"})
+	for _, container := range oneOffs {
+		age := ""
+		if container.CreatedAt != nil {
+			age = time.Since(*container.CreatedAt).Round(time.Second).String()
+		}
+		t.Append([]string{container.Label, container.State, container.Command, container.ContainerSize.HumanName, age})
+	}
+	t.Render()
+	return nil
+}
+
+// OneOffLogs streams the logs of a one-off container
+func OneOffLogs(ctx context.Context, app, label string, lines int) error {
+	return Logs(ctx, app, true, lines, label)
+}
+
+// OneOffWait blocks until the one-off container is done and returns its exit
+// code. The exit code is only known for the one-offs started detached from this
+// computer, -1 is returned otherwise.
+func OneOffWait(ctx context.Context, app, label string) (int, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return -1, errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	oneOff, tracked, err := findDetachedOneOff(app, label)
+	if err != nil {
+		debug.Println("fail to read the detached one-offs:", err)
+	}
+	if tracked && oneOff.AttachURL != "" {
+		io.Statusf("Waiting for the one-off %s to exit...\n", io.Bold(label))
+		runCtx := &runContext{app: app, attachURL: oneOff.AttachURL, scalingoClient: c}
+		exitCode, err := runCtx.exitCode(ctx)
+		if err == nil {
+			untrackDetachedOneOff(app, label)
+			return exitCode, nil
+		}
+		debug.Println("fail to get the exit code of the one-off:", err)
+	}
+
+	found, err := oneOffRunning(ctx, c, app, label)
+	if err != nil {
+		return -1, errgo.Mask(err)
+	}
+	if !found {
+		return -1, errgo.Newf("the one-off %s is not running", label)
+	}
+
+	io.Statusf("Waiting for the one-off %s to exit...\n", io.Bold(label))
+	for found {
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(oneOffWaitInterval):
+		}
+		found, err = oneOffRunning(ctx, c, app, label)
+		if err != nil {
+			return -1, errgo.Mask(err)
+		}
+	}
+	untrackDetachedOneOff(app, label)
+	return -1, nil
+}
+
+func oneOffRunning(ctx context.Context, c *scalingo.Client, app, label string) (bool, error) {
+	containers, err := c.AppsContainersPs(ctx, app)
+	if err != nil {
+		return false, errgo.Notef(err, "fail to list the application containers")
+	}
+	for _, container := range containers {
+		if container.Label == label {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func readDetachedOneOffs() ([]detachedOneOff, error) {
+	content, err := os.ReadFile(detachedOneOffsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the detached one-offs")
+	}
+
+	var oneOffs []detachedOneOff
+	err = json.Unmarshal(content, &oneOffs)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the detached one-offs")
+	}
+	return oneOffs, nil
+}
+
+func writeDetachedOneOffs(oneOffs []detachedOneOff) error {
+	content, err := json.MarshalIndent(oneOffs, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the detached one-offs")
+	}
+	err = os.WriteFile(detachedOneOffsFile, content, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the detached one-offs")
+	}
+	return nil
+}
+
+// trackDetachedOneOff keeps the one-off locally. The one-offs older than a week
+// are forgotten, they are not running anymore.
+func trackDetachedOneOff(oneOff detachedOneOff) error {
+	oneOffs, err := readDetachedOneOffs()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	kept := []detachedOneOff{}
+	for _, o := range oneOffs {
+		if time.Since(o.CreatedAt) < 7*24*time.Hour {
+			kept = append(kept, o)
+		}
+	}
+	return writeDetachedOneOffs(append(kept, oneOff))
+}
+
+// findDetachedOneOff returns the one-off labeled label of the application in
+// the current region
+func findDetachedOneOff(app, label string) (detachedOneOff, bool, error) {
+	oneOffs, err := readDetachedOneOffs()
+	if err != nil {
+		return detachedOneOff{}, false, errgo.Mask(err)
+	}
+	for _, oneOff := range oneOffs {
+		if oneOff.App == app && oneOff.Label == label && oneOff.Region == config.C.ScalingoRegion {
+			return oneOff, true, nil
+		}
+	}
+	return detachedOneOff{}, false, nil
+}
+
+func untrackDetachedOneOff(app, label string) {
+	oneOffs, err := readDetachedOneOffs()
+	if err != nil {
+		debug.Println(err)
+		return
+	}
+	kept := []detachedOneOff{}
+	for _, oneOff := range oneOffs {
+		if oneOff.App == app && oneOff.Label == label && oneOff.Region == config.C.ScalingoRegion {
+			continue
+		}
+		kept = append(kept, oneOff)
+	}
+	err = writeDetachedOneOffs(kept)
+	if err != nil {
+		debug.Println(err)
+	}
+}
+
+// OneOffLabel returns the label of a one-off from the value given by the user,
+// which can be only its number: 1234 is the one-off-1234
+func OneOffLabel(value string) string {
+	if value == "" {
+		return value
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return value
+		}
+	}
+	return fmt.Sprintf("%s-%s", oneOffContainerType, value)
+}
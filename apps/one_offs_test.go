@@ -0,0 +1,25 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOneOffLabel(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		label string
+	}{
+		"with the number only": {value: "1234", label: "one-off-1234"},
+		"with the label":       {value: "one-off-1234", label: "one-off-1234"},
+		"with another label":   {value: "web-1", label: "web-1"},
+		"with an empty value":  {value: "", label: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.label, OneOffLabel(test.value))
+		})
+	}
+}
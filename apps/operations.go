@@ -32,6 +32,22 @@ func NewOperationWaiterFromHTTPResponse(app string, res *http.Response) *Operati
 	return NewOperationWaiterFromURL(app, operationURL)
 }
 
+// NewOperationWaiterFromID returns a waiter of an operation which has been
+// started previously, like the ones listed by the 'operations' command
+func NewOperationWaiterFromID(app, operationID string) *OperationWaiter {
+	return NewOperationWaiterFromURL(app, operationID)
+}
+
+// OperationIDFromHTTPResponse extracts the ID of the operation started by
+// the request from the Location header of its response
+func OperationIDFromHTTPResponse(res *http.Response) string {
+	operationURL, err := url.Parse(res.Header.Get("Location"))
+	if err != nil || operationURL.Path == "" {
+		return ""
+	}
+	return filepath.Base(operationURL.Path)
+}
+
 func NewOperationWaiterFromURL(app, url string) *OperationWaiter {
 	return NewOperationWaiter(os.Stderr, app, url)
 }
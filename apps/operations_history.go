@@ -0,0 +1,180 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+const (
+	// operationsHistorySize is the maximal amount of operations kept in the
+	// history
+	operationsHistorySize = 200
+
+	operationTypeRestart scalingo.OperationType = "restart"
+)
+
+var (
+	operationsHistoryFile = filepath.Join(config.C.CacheDir, "operations.json")
+)
+
+// trackedOperation is an operation started from the CLI. The Scalingo API
+// does not list the operations of an application, the ones started from the
+// CLI are kept locally so that they can be listed and waited for later.
+type trackedOperation struct {
+	ID        string                 `json:"id"`
+	App       string                 `json:"app"`
+	Region    string                 `json:"region"`
+	Type      scalingo.OperationType `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+func readOperationsHistory() ([]trackedOperation, error) {
+	fd, err := os.Open(operationsHistoryFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to open the operations history")
+	}
+	defer fd.Close()
+
+	var operations []trackedOperation
+	err = json.NewDecoder(fd).Decode(&operations)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the operations history")
+	}
+	return operations, nil
+}
+
+// errNoOperationID is returned when the ID of the operation is required but
+// the response of the Scalingo API does not contain it
+var errNoOperationID = errgo.New("the Scalingo API did not return the ID of the operation")
+
+// displayOperationID prints the ID of the operation to wait for it later, or a
+// notice if the response of the Scalingo API does not contain it
+func displayOperationID(app, operationID string) {
+	if operationID == "" {
+		io.Warningf("The ID of the operation is not available, check the state of the containers with 'scalingo --app %s ps'\n", app)
+		return
+	}
+	fmt.Printf("Operation ID: %s\n", operationID)
+}
+
+// trackOperation adds the operation to the history of the operations started
+// from the CLI. Failing to do so must not fail the command which started the
+// operation, hence the error is only logged.
+func trackOperation(app string, operationID string, operationType scalingo.OperationType) {
+	if operationID == "" {
+		return
+	}
+
+	operations, err := readOperationsHistory()
+	if err != nil {
+		debug.Println("[Operations] Reset the operations history:", err)
+	}
+	operations = append(operations, trackedOperation{
+		ID:        operationID,
+		App:       app,
+		Region:    config.C.ScalingoRegion,
+		Type:      operationType,
+		CreatedAt: time.Now(),
+	})
+	if len(operations) > operationsHistorySize {
+		operations = operations[len(operations)-operationsHistorySize:]
+	}
+
+	fd, err := os.OpenFile(operationsHistoryFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		debug.Println("[Operations] Fail to open the operations history:", err)
+		return
+	}
+	defer fd.Close()
+
+	err = json.NewEncoder(fd).Encode(operations)
+	if err != nil {
+		debug.Println("[Operations] Fail to write the operations history:", err)
+	}
+}
+
+// OperationsList displays the status of the operations of the application
+// which have been started from the CLI, most recent first
+func OperationsList(ctx context.Context, app string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	history, err := readOperationsHistory()
+	if err != nil {
+		return errgo.Notef(err, "fail to read the operations history")
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"ID", "Type", "Status", "Created At", "Duration", "Error"})
+
+	count := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		tracked := history[i]
+		if tracked.App != app || tracked.Region != config.C.ScalingoRegion {
+			continue
+		}
+		count++
+
+		op, err := c.OperationsShow(ctx, app, tracked.ID)
+		if err != nil {
+			debug.Printf("[Operations] Fail to get operation %v: %v\n", tracked.ID, err)
+			t.Append([]string{tracked.ID, string(tracked.Type), "unknown", tracked.CreatedAt.Format(utils.TimeFormat), "", ""})
+			continue
+		}
+
+		duration := ""
+		if !op.FinishedAt.IsZero() {
+			duration = fmt.Sprintf("%.3fs", op.ElapsedDuration())
+		}
+		t.Append([]string{op.ID, string(op.Type), string(op.Status), op.CreatedAt.Local().Format(utils.TimeFormat), duration, op.Error})
+	}
+
+	if count == 0 {
+		io.Statusf("No operation of the app '%s' has been started from this computer.\n", app)
+		return nil
+	}
+	t.Render()
+	return nil
+}
+
+// OperationsWait waits for the end of each of the given operations. It
+// returns an error if one of them failed.
+func OperationsWait(ctx context.Context, app string, operationIDs []string) error {
+	failed := []string{}
+	for _, operationID := range operationIDs {
+		waiter := NewOperationWaiterFromID(app, operationID)
+		waiter.SetPrompt(fmt.Sprintf("Operation %s: ", operationID))
+		op, err := waiter.WaitOperation(ctx)
+		if err != nil {
+			// A nil operation means that it could not be fetched at all,
+			// otherwise the failure has already been displayed by the waiter
+			if op == nil {
+				io.Errorf("Fail to get operation %s: %v\n", operationID, err)
+			}
+			failed = append(failed, operationID)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errgo.Newf("%d operation(s) failed: %v", len(failed), failed)
+	}
+	return nil
+}
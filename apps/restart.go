@@ -3,6 +3,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gopkg.in/errgo.v1"
 
@@ -10,7 +11,20 @@ import (
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func Restart(ctx context.Context, app string, sync bool, args []string) error {
+type RestartOpts struct {
+	// Sync waits for the end of the restart operation
+	Sync bool
+	// Async only prints the ID of the restart operation, to be waited later
+	// with 'operation-wait'
+	Async bool
+	// Rolling restarts the containers by batches of Batch containers, waiting
+	// Interval between two batches
+	Rolling  bool
+	Batch    int
+	Interval time.Duration
+}
+
+func Restart(ctx context.Context, app string, opts RestartOpts, args []string) error {
 	params := scalingo.AppsRestartParams{Scope: args}
 
 	c, err := config.ScalingoClient(ctx)
@@ -18,14 +32,29 @@ func Restart(ctx context.Context, app string, sync bool, args []string) error {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
 
+	if opts.Rolling {
+		return rollingRestart(ctx, c, app, opts, args)
+	}
+
 	res, err := c.AppsRestart(ctx, app, &params)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
 	res.Body.Close()
 
-	if !sync {
+	operationID := OperationIDFromHTTPResponse(res)
+	trackOperation(app, operationID, operationTypeRestart)
+	if opts.Async {
+		if operationID == "" {
+			return errNoOperationID
+		}
+		fmt.Println(operationID)
+		return nil
+	}
+
+	if !opts.Sync {
 		fmt.Println("Your application is being restarted.")
+		displayOperationID(app, operationID)
 		return nil
 	}
 
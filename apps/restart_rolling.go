@@ -0,0 +1,173 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	rollingRestartPollInterval = 2 * time.Second
+	// rollingRestartBatchTimeout is the maximum duration for the containers of
+	// a batch to be running again, the rolling restart is stopped after it
+	rollingRestartBatchTimeout = 5 * time.Minute
+)
+
+// rollingRestart restarts the containers matching the scope batch after batch.
+// Each batch is restarted with a restart operation scoped to its containers
+// labels, the next batch is only restarted once the containers of the previous
+// one are running again and the interval is elapsed.
+func rollingRestart(ctx context.Context, c *scalingo.Client, app string, opts RestartOpts, scope []string) error {
+	containers, err := c.AppsContainersPs(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the application containers")
+	}
+
+	batches := rollingRestartBatches(containers, scope, opts.Batch)
+	if len(batches) == 0 {
+		return errgo.Newf("no container matches %s", strings.Join(scope, ", "))
+	}
+
+	for i, batch := range batches {
+		if i > 0 && opts.Interval > 0 {
+			io.Statusf("Waiting %v before the next batch\n", opts.Interval)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.Interval):
+			}
+		}
+
+		io.Statusf("Restarting %s (batch %d/%d)\n", io.Bold(strings.Join(batch, ", ")), i+1, len(batches))
+		res, err := c.AppsRestart(ctx, app, &scalingo.AppsRestartParams{Scope: batch})
+		if err != nil {
+			return errgo.Notef(err, "fail to restart %s", strings.Join(batch, ", "))
+		}
+		res.Body.Close()
+		trackOperation(app, OperationIDFromHTTPResponse(res), operationTypeRestart)
+
+		_, err = NewOperationWaiterFromHTTPResponse(app, res).WaitOperation(ctx)
+		if err != nil {
+			return errgo.Notef(err, "fail to restart %s, the rolling restart is stopped", strings.Join(batch, ", "))
+		}
+
+		err = waitContainersRunning(ctx, c, app, batch)
+		if err != nil {
+			return errgo.Notef(err, "the rolling restart is stopped")
+		}
+		io.Statusf("%s running\n", strings.Join(batch, ", "))
+	}
+
+	fmt.Println("Your application has been restarted.")
+	return nil
+}
+
+// rollingRestartBatches returns the labels of the containers matching the
+// scope grouped by batches of batchSize. The scope contains types of
+// containers (web) or labels (web-1), all the containers but the one-offs
+// match an empty scope.
+func rollingRestartBatches(containers []scalingo.Container, scope []string, batchSize int) [][]string {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	labels := []string{}
+	for _, container := range containers {
+		if container.Type == oneOffContainerType {
+			continue
+		}
+		if len(scope) == 0 || containerInScope(container, scope) {
+			labels = append(labels, container.Label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return containerLabelLess(labels[i], labels[j])
+	})
+
+	batches := [][]string{}
+	for len(labels) > 0 {
+		size := batchSize
+		if size > len(labels) {
+			size = len(labels)
+		}
+		batches = append(batches, labels[:size])
+		labels = labels[size:]
+	}
+	return batches
+}
+
+func containerInScope(container scalingo.Container, scope []string) bool {
+	for _, s := range scope {
+		if s == container.Type || s == container.Label {
+			return true
+		}
+	}
+	return false
+}
+
+// containerLabelLess sorts the labels by type then by index: web-2 is before
+// web-10
+func containerLabelLess(a, b string) bool {
+	aType, aIndex := splitContainerLabel(a)
+	bType, bIndex := splitContainerLabel(b)
+	if aType != bType {
+		return aType < bType
+	}
+	return aIndex < bIndex
+}
+
+func splitContainerLabel(label string) (string, int) {
+	i := strings.LastIndex(label, "-")
+	if i < 0 {
+		return label, 0
+	}
+	index := 0
+	_, err := fmt.Sscanf(label[i+1:], "%d", &index)
+	if err != nil {
+		return label, 0
+	}
+	return label[:i], index
+}
+
+// waitContainersRunning waits for all the containers labeled labels to be
+// running
+func waitContainersRunning(ctx context.Context, c *scalingo.Client, app string, labels []string) error {
+	timeout := time.After(rollingRestartBatchTimeout)
+	for {
+		containers, err := c.AppsContainersPs(ctx, app)
+		if err != nil {
+			return errgo.Notef(err, "fail to list the application containers")
+		}
+		notRunning := []string{}
+		for _, label := range labels {
+			running := false
+			for _, container := range containers {
+				if container.Label == label && container.State == "running" {
+					running = true
+					break
+				}
+			}
+			if !running {
+				notRunning = append(notRunning, label)
+			}
+		}
+		if len(notRunning) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return errgo.Newf("%s not running after %v", strings.Join(notRunning, ", "), rollingRestartBatchTimeout)
+		case <-time.After(rollingRestartPollInterval):
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestRollingRestartBatches(t *testing.T) {
+	containers := []scalingo.Container{
+		{Label: "web-10", Type: "web"},
+		{Label: "web-2", Type: "web"},
+		{Label: "web-1", Type: "web"},
+		{Label: "worker-1", Type: "worker"},
+		{Label: "one-off-1234", Type: "one-off"},
+	}
+
+	tests := map[string]struct {
+		scope     []string
+		batchSize int
+		expected  [][]string
+	}{
+		"all the containers but the one-offs": {
+			batchSize: 2,
+			expected:  [][]string{{"web-1", "web-2"}, {"web-10", "worker-1"}},
+		},
+		"a type of containers": {
+			scope:     []string{"web"},
+			batchSize: 1,
+			expected:  [][]string{{"web-1"}, {"web-2"}, {"web-10"}},
+		},
+		"a type and a label": {
+			scope:     []string{"worker", "web-2"},
+			batchSize: 3,
+			expected:  [][]string{{"web-2", "worker-1"}},
+		},
+		"an invalid batch size": {
+			scope:     []string{"worker"},
+			batchSize: 0,
+			expected:  [][]string{{"worker-1"}},
+		},
+		"no matching container": {
+			scope:     []string{"clock"},
+			batchSize: 1,
+			expected:  [][]string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, rollingRestartBatches(containers, test.scope, test.batchSize))
+		})
+	}
+}
@@ -19,11 +19,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	errgo "gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/apps/run"
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/containersizes"
 	"github.com/Scalingo/cli/httpclient"
 	"github.com/Scalingo/cli/io"
 	"github.com/Scalingo/cli/signals"
@@ -44,8 +46,15 @@ type RunOpts struct {
 	Cmd            []string
 	CmdEnv         []string
 	Files          []string
+	Record         bool
+	Downloads      []RunDownload
+	NoTTY          bool
+	Timeout        time.Duration
 	StdinCopyFunc  func(stdio.Writer, stdio.Reader) (int64, error)
 	StdoutCopyFunc func(stdio.Writer, stdio.Reader) (int64, error)
+	// ExitFunc is called with the exit code of an attached one-off once it is
+	// done, os.Exit is used if it is not set
+	ExitFunc func(int)
 }
 
 type runContext struct {
@@ -88,6 +97,11 @@ func Run(ctx context.Context, opts RunOpts) error {
 
 	if opts.Size == "" {
 		opts.Size = "M"
+	} else {
+		err = containersizes.Validate(ctx, c, opts.Size)
+		if err != nil {
+			return errgo.Mask(err)
+		}
 	}
 
 	if opts.CmdEnv == nil {
@@ -113,12 +127,51 @@ func Run(ctx context.Context, opts RunOpts) error {
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
+	if opts.NoTTY {
+		// The output is consumed by a script, the remote programs must not use
+		// colors or pagers
+		if !envDefined(opts.CmdEnv, "TERM") {
+			env["TERM"] = "dumb"
+		}
+	}
 
 	err = runCtx.validateFiles(opts.Files)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
 
+	displayCmd := opts.DisplayCmd
+	if displayCmd == "" {
+		displayCmd = strings.Join(opts.Cmd, " ")
+	}
+
+	var downloads *runDownloads
+	if len(opts.Downloads) > 0 && !opts.Detached {
+		downloads, err = newRunDownloads(opts.Downloads)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		defer downloads.cleanup()
+		opts.Cmd = []string{downloads.wrapCommand(strings.Join(opts.Cmd, " "))}
+	}
+
+	// The recording is created before starting the one-off so that no session
+	// can happen without its recording
+	var recorder *runRecorder
+	if opts.Record && !opts.Detached {
+		var recordingPath string
+		recorder, recordingPath, err = createRunRecording(opts.App, opts.Size, displayCmd)
+		if err != nil {
+			return errgo.Notef(err, "fail to record the session")
+		}
+		defer func() {
+			if recorder != nil {
+				recorder.Close()
+			}
+		}()
+		fmt.Fprintf(runCtx.waitingTextOutputWriter, "-----> Recording the session in %s\n", recordingPath)
+	}
+
 	runRes, err := c.Run(
 		ctx,
 		scalingo.RunOpts{
@@ -137,11 +190,24 @@ func Run(ctx context.Context, opts RunOpts) error {
 	debug.Printf("%+v\n", runRes)
 
 	if opts.Detached {
+		err = trackDetachedOneOff(detachedOneOff{
+			App:       opts.App,
+			Region:    config.C.ScalingoRegion,
+			Label:     runRes.Container.Label,
+			AttachURL: runRes.AttachURL,
+			Command:   strings.Join(opts.Cmd, " "),
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			debug.Println("fail to track the detached one-off:", err)
+		}
 		fmt.Printf(
 			"Starting one-off '%s' for app '%v'.\n"+
-				"Run `scalingo --region %v --app %v logs --filter %v` to get the output\n",
+				"Run `scalingo --region %v --app %v one-off-logs %v` to get the output\n"+
+				"Run `scalingo --region %v --app %v one-off-wait %v` to wait for its exit code\n",
 			io.Bold(strings.Join(opts.Cmd, " ")), io.Bold(opts.App),
 			config.C.ScalingoRegion, opts.App, runRes.Container.Label,
+			config.C.ScalingoRegion, opts.App, runRes.Container.Label,
 		)
 		return nil
 	}
@@ -170,12 +236,6 @@ func Run(ctx context.Context, opts RunOpts) error {
 
 	attachSpinner := io.NewSpinner(runCtx.waitingTextOutputWriter)
 	attachSpinner.PostHook = func() {
-		var displayCmd string
-		if opts.DisplayCmd != "" {
-			displayCmd = opts.DisplayCmd
-		} else {
-			displayCmd = strings.Join(opts.Cmd, " ")
-		}
 		fmt.Fprintf(runCtx.waitingTextOutputWriter, "\n-----> Process '%v' is starting...  ", displayCmd)
 	}
 	go attachSpinner.Start()
@@ -189,12 +249,19 @@ func Run(ctx context.Context, opts RunOpts) error {
 		return errgo.Newf("Fail to attach: %s", res.Status)
 	}
 
-	if term.IsATTY(os.Stdin) {
+	rawTerminal := !opts.NoTTY && term.IsATTY(os.Stdin)
+	if rawTerminal {
 		if err := term.MakeRaw(os.Stdin); err != nil {
 			return errgo.Mask(err, errgo.Any)
 		}
 	}
 
+	var timeout *runTimeout
+	if opts.Timeout > 0 {
+		timeout = newRunTimeout(ctx, runCtx, runRes.Container, socket, opts.Timeout)
+		defer timeout.stop()
+	}
+
 	stopSignalsMonitoring := make(chan bool)
 	defer close(stopSignalsMonitoring)
 
@@ -219,7 +286,9 @@ func Run(ctx context.Context, opts RunOpts) error {
 	startSpinner := io.NewSpinnerWithStopChan(runCtx.waitingTextOutputWriter, firstReadDone)
 	// This method will be executed after first read
 	startSpinner.PostHook = func() {
-		go run.NotifyTermSizeUpdate(signals)
+		if !opts.NoTTY {
+			go run.NotifyTermSizeUpdate(signals)
+		}
 		fmt.Fprintf(runCtx.waitingTextOutputWriter, "\n\n")
 	}
 	go startSpinner.Start()
@@ -228,32 +297,82 @@ func Run(ctx context.Context, opts RunOpts) error {
 		_, err := runCtx.stdinCopyFunc(socket, os.Stdin)
 		if err != nil {
 			debug.Println("error after reading stdin", err)
-		} else {
-			// Send EOT when stdin returns
-			// 'scalingo run < file'
-			socket.Write([]byte("\x04"))
+			return
+		}
+		if opts.NoTTY {
+			// The end of the input is only notified with a half-close, an EOT
+			// byte would be part of the data piped to the command. The output
+			// is still read until the command is done.
+			if conn, ok := socket.(interface{ CloseWrite() error }); ok {
+				err := conn.CloseWrite()
+				if err != nil {
+					debug.Println("fail to half-close the connection", err)
+				}
+			}
+			return
 		}
+		// Send EOT when stdin returns
+		// 'scalingo run < file'
+		socket.Write([]byte("\x04"))
 	}()
 
-	_, err = runCtx.stdoutCopyFunc(os.Stdout, socket)
-	if err != nil {
+	var stdout stdio.Writer = os.Stdout
+	if recorder != nil {
+		stdout = stdio.MultiWriter(os.Stdout, recorder)
+	}
+	if downloads != nil {
+		stdout = downloads.filter(stdout)
+	}
+	_, err = runCtx.stdoutCopyFunc(stdout, socket)
+	if err != nil && !timeout.expired() {
 		return errgo.Mask(err, errgo.Any)
 	}
 
+	// The process exits right after the exit code is fetched, the recording
+	// must be complete before
+	if recorder != nil {
+		err := recorder.Close()
+		recorder = nil
+		if err != nil {
+			io.Warning("The recording of the session is incomplete:", err)
+		}
+	}
+
 	stopSignalsMonitoring <- true
 
-	if term.IsATTY(os.Stdin) {
+	if rawTerminal {
 		if err := term.Restore(os.Stdin); err != nil {
 			return errgo.Mask(err, errgo.Any)
 		}
 	}
 
+	if timeout.expired() {
+		return errgo.Newf("the one-off %s has been stopped after the %v timeout", runRes.Container.Label, opts.Timeout)
+	}
+
+	if downloads != nil {
+		archive, err := downloads.archive()
+		if err != nil {
+			return errgo.Notef(err, "fail to download the files")
+		}
+		err = downloads.extract(archive)
+		if err != nil {
+			return errgo.Notef(err, "fail to download the files")
+		}
+		// The process exits before the deferred calls are run
+		downloads.cleanup()
+	}
+
 	exitCode, err := runCtx.exitCode(ctx)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	defer os.Exit(exitCode)
+	exit := opts.ExitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	defer exit(exitCode)
 	return nil
 }
 
@@ -273,6 +392,16 @@ func (runCtx *runContext) buildEnv(cmdEnv []string) (map[string]string, error) {
 	return env, nil
 }
 
+// envDefined returns true if the variable is defined with the '--env' flag
+func envDefined(cmdEnv []string, name string) bool {
+	for _, cmdVar := range cmdEnv {
+		if strings.HasPrefix(cmdVar, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 func (runCtx *runContext) exitCode(ctx context.Context) (int, error) {
 	if runCtx.attachURL == "" {
 		return -1, errgo.New("No attach URL to connect to")
@@ -0,0 +1,420 @@
+package apps
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	stdio "io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cheggaaa/pb/v3"
+	errgo "gopkg.in/errgo.v1"
+)
+
+// RunDownload is a file or a directory retrieved from the one-off container
+// once its command is done
+type RunDownload struct {
+	Remote string
+	Local  string
+}
+
+// ParseRunDownload parses the value of the --download flag: remote:local
+func ParseRunDownload(value string) (RunDownload, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return RunDownload{}, errgo.Newf("invalid download '%s', the format is '--download /remote/path:local/path'", value)
+	}
+	return RunDownload{Remote: parts[0], Local: parts[1]}, nil
+}
+
+// entryName is the name of the remote path in the archive
+func (d RunDownload) entryName() string {
+	return strings.TrimPrefix(path.Clean(d.Remote), "/")
+}
+
+// runDownloads retrieves files from the one-off container. The command of the
+// one-off is wrapped so that, once done, the files are packed in a tar.gz
+// archive which is written base64 encoded on the attach connection between two
+// markers, with its size and checksum. The archive is filtered out of the
+// output of the command and decoded while it is received into a temporary
+// file, which is then checked and extracted locally.
+type runDownloads struct {
+	downloads []RunDownload
+	token     string
+	// progressWriter displays the progress of the download
+	progressWriter stdio.Writer
+
+	// pending is the output which may be the beginning of a marker
+	pending   []byte
+	capturing bool
+	done      bool
+	failed    bool
+	size      int64
+	checksum  string
+	bar       *pb.ProgressBar
+
+	// encoded is the end of the received archive which is not a complete
+	// base64 quantum yet
+	encoded  []byte
+	file     *os.File
+	hash     hash.Hash
+	received int64
+	// err is the error which occurred while receiving the archive, the rest of
+	// the archive is still filtered out of the output
+	err error
+}
+
+func newRunDownloads(downloads []RunDownload) (*runDownloads, error) {
+	buf := make([]byte, 8)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to generate the download token")
+	}
+	return &runDownloads{downloads: downloads, token: hex.EncodeToString(buf), progressWriter: os.Stderr}, nil
+}
+
+func (d *runDownloads) beginMarker() string {
+	return "SCALINGO-DOWNLOAD-BEGIN-" + d.token
+}
+
+func (d *runDownloads) endMarker() string {
+	return "SCALINGO-DOWNLOAD-END-" + d.token
+}
+
+func (d *runDownloads) failedMarker() string {
+	return "SCALINGO-DOWNLOAD-FAILED-" + d.token
+}
+
+// wrapCommand returns the command run in the one-off container: cmd followed
+// by the packing of the downloaded paths. The exit code of cmd is kept.
+func (d *runDownloads) wrapCommand(cmd string) string {
+	tarArgs := []string{}
+	for _, download := range d.downloads {
+		if path.IsAbs(download.Remote) {
+			tarArgs = append(tarArgs, "-C", "/", shellQuote(download.entryName()))
+		} else {
+			tarArgs = append(tarArgs, "-C", `"$scalingo_pwd"`, shellQuote(download.entryName()))
+		}
+	}
+
+	// The command is run in a subshell so that the files are packed even if
+	// it exits the shell
+	return strings.Join([]string{
+		`scalingo_pwd="$PWD"`,
+		"(" + cmd + ")",
+		`scalingo_status=$?`,
+		`scalingo_archive=$(mktemp)`,
+		fmt.Sprintf(
+			`if tar -czf "$scalingo_archive" %s; then printf '%s %%s %%s\n' "$(wc -c < "$scalingo_archive")" "$(sha256sum "$scalingo_archive" | cut -d' ' -f1)"; base64 "$scalingo_archive"; printf '%s\n'; else printf '%s\n'; fi`,
+			strings.Join(tarArgs, " "), d.beginMarker(), d.endMarker(), d.failedMarker(),
+		),
+		`rm -f "$scalingo_archive"`,
+		`exit $scalingo_status`,
+	}, "; ")
+}
+
+// filter returns a writer forwarding the output of the one-off to w without
+// the archive
+func (d *runDownloads) filter(w stdio.Writer) stdio.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		err := d.write(w, p)
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	})
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func (d *runDownloads) write(w stdio.Writer, p []byte) error {
+	d.pending = append(d.pending, p...)
+	for len(d.pending) > 0 {
+		if d.capturing {
+			if !d.capture() {
+				return nil
+			}
+			continue
+		}
+		if d.done {
+			_, err := w.Write(d.pending)
+			d.pending = nil
+			return err
+		}
+
+		beginIndex := bytes.Index(d.pending, []byte(d.beginMarker()))
+		failedIndex := bytes.Index(d.pending, []byte(d.failedMarker()))
+		switch {
+		case failedIndex >= 0 && (beginIndex < 0 || failedIndex < beginIndex):
+			_, err := w.Write(d.pending[:failedIndex])
+			if err != nil {
+				return err
+			}
+			d.pending = skipLine(d.pending[failedIndex:])
+			d.done, d.failed = true, true
+		case beginIndex >= 0:
+			_, err := w.Write(d.pending[:beginIndex])
+			if err != nil {
+				return err
+			}
+			d.pending = d.pending[beginIndex:]
+			d.capturing = true
+		default:
+			// The end of the output is kept until it cannot be the beginning of
+			// a marker anymore
+			keep := partialMarkerLength(d.pending, d.beginMarker(), d.failedMarker())
+			_, err := w.Write(d.pending[:len(d.pending)-keep])
+			if err != nil {
+				return err
+			}
+			d.pending = d.pending[len(d.pending)-keep:]
+			return nil
+		}
+	}
+	return nil
+}
+
+// capture consumes the archive in pending. It returns false if more output is
+// needed.
+func (d *runDownloads) capture() bool {
+	if d.bar == nil {
+		lineEnd := bytes.IndexByte(d.pending, '\n')
+		if lineEnd < 0 {
+			return false
+		}
+		fields := strings.Fields(string(d.pending[:lineEnd]))
+		d.pending = d.pending[lineEnd+1:]
+		if len(fields) == 3 {
+			d.size, _ = strconv.ParseInt(fields[1], 10, 64)
+			d.checksum = fields[2]
+		}
+		d.file, d.err = os.CreateTemp("", "scalingo-download-*.tar.gz")
+		if d.err != nil {
+			d.err = errgo.Notef(d.err, "fail to create the temporary file of the archive")
+		}
+		d.hash = sha256.New()
+		d.bar = pb.New64(d.size).Set(pb.Bytes, true).SetWriter(d.progressWriter)
+		d.bar.Start()
+	}
+
+	endIndex := bytes.Index(d.pending, []byte(d.endMarker()))
+	if endIndex < 0 {
+		// The end marker may be split between two writes
+		keep := partialMarkerLength(d.pending, d.endMarker())
+		d.decode(d.pending[:len(d.pending)-keep])
+		d.pending = d.pending[len(d.pending)-keep:]
+		d.bar.SetCurrent(d.received)
+		return false
+	}
+
+	d.decode(d.pending[:endIndex])
+	d.pending = skipLine(d.pending[endIndex:])
+	d.bar.SetCurrent(d.received)
+	d.bar.Finish()
+	d.capturing, d.done = false, true
+	return true
+}
+
+// decode writes the complete base64 quanta of the encoded archive to the
+// temporary file
+func (d *runDownloads) decode(encoded []byte) {
+	for _, b := range encoded {
+		if b != '\r' && b != '\n' && b != ' ' {
+			d.encoded = append(d.encoded, b)
+		}
+	}
+	length := len(d.encoded) / 4 * 4
+	if length == 0 {
+		return
+	}
+	quanta := d.encoded[:length]
+	defer func() {
+		d.encoded = append(d.encoded[:0], d.encoded[length:]...)
+	}()
+	if d.err != nil {
+		return
+	}
+
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(length))
+	n, err := base64.StdEncoding.Decode(decoded, quanta)
+	if err != nil {
+		d.err = errgo.Notef(err, "the archive received is corrupted")
+		return
+	}
+	_, err = stdio.MultiWriter(d.file, d.hash).Write(decoded[:n])
+	if err != nil {
+		d.err = errgo.Notef(err, "fail to write the archive to %s", d.file.Name())
+		return
+	}
+	d.received += int64(n)
+}
+
+// archive returns the path of the downloaded archive after checking its
+// integrity
+func (d *runDownloads) archive() (string, error) {
+	if d.failed {
+		return "", errgo.New("the files could not be packed in the one-off container, check that the paths exist")
+	}
+	if !d.done {
+		return "", errgo.New("the files have not been received, the connection may have been interrupted")
+	}
+	if d.err != nil {
+		return "", d.err
+	}
+	if len(d.encoded) != 0 {
+		return "", errgo.New("the archive received is corrupted: it is truncated")
+	}
+	if d.received != d.size {
+		return "", errgo.Newf("the archive received is corrupted: %d bytes received instead of %d", d.received, d.size)
+	}
+	if hex.EncodeToString(d.hash.Sum(nil)) != d.checksum {
+		return "", errgo.New("the archive received is corrupted: its checksum does not match")
+	}
+	return d.file.Name(), nil
+}
+
+// cleanup removes the temporary file of the archive
+func (d *runDownloads) cleanup() {
+	if d.file == nil {
+		return
+	}
+	d.file.Close()
+	os.Remove(d.file.Name())
+	d.file = nil
+}
+
+// extract writes the files of the archive to their local path. The links are
+// not created, they are only reported.
+func (d *runDownloads) extract(archivePath string) error {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return errgo.Notef(err, "fail to open the archive")
+	}
+	defer archive.Close()
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return errgo.Notef(err, "fail to decompress the archive")
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == stdio.EOF {
+			break
+		}
+		if err != nil {
+			return errgo.Notef(err, "fail to read the archive")
+		}
+
+		target, err := d.localPath(header.Name)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+			if err != nil {
+				return errgo.Notef(err, "fail to create the directory %s", target)
+			}
+		case tar.TypeReg:
+			err = writeDownloadedFile(target, tarReader, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return errgo.Mask(err)
+			}
+			fmt.Fprintf(d.progressWriter, "-----> %s downloaded to %s\n", header.Name, target)
+		case tar.TypeSymlink:
+			fmt.Fprintf(d.progressWriter, "-----> %s is a symbolic link to %s, it has not been downloaded\n", header.Name, header.Linkname)
+		case tar.TypeLink:
+			fmt.Fprintf(d.progressWriter, "-----> %s is a hard link to %s, it has not been downloaded\n", header.Name, header.Linkname)
+		default:
+			fmt.Fprintf(d.progressWriter, "-----> %s is not a regular file, it has not been downloaded\n", header.Name)
+		}
+	}
+	return nil
+}
+
+// localPath returns the local path of the archive entry name
+func (d *runDownloads) localPath(name string) (string, error) {
+	name = strings.TrimSuffix(path.Clean(name), "/")
+	for _, download := range d.downloads {
+		entry := download.entryName()
+		if name != entry && !strings.HasPrefix(name, entry+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(name, entry)
+		if rest == "" {
+			// The remote path is a file downloaded in an existing directory
+			if stat, err := os.Stat(download.Local); err == nil && stat.IsDir() {
+				return filepath.Join(download.Local, path.Base(entry)), nil
+			}
+			return download.Local, nil
+		}
+		for _, segment := range strings.Split(rest, "/") {
+			if segment == ".." {
+				return "", errgo.Newf("invalid path %s in the archive", name)
+			}
+		}
+		return filepath.Join(download.Local, filepath.FromSlash(rest)), nil
+	}
+	return "", errgo.Newf("unexpected file %s in the archive", name)
+}
+
+func writeDownloadedFile(target string, reader stdio.Reader, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return errgo.Notef(err, "fail to create the directory of %s", target)
+	}
+	fd, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return errgo.Notef(err, "fail to create %s", target)
+	}
+	defer fd.Close()
+	_, err = stdio.Copy(fd, reader)
+	if err != nil {
+		return errgo.Notef(err, "fail to write %s", target)
+	}
+	return nil
+}
+
+// partialMarkerLength returns the length of the longest end of output which is
+// the beginning of one of the markers
+func partialMarkerLength(output []byte, markers ...string) int {
+	longest := 0
+	for _, marker := range markers {
+		for length := len(marker) - 1; length > longest; length-- {
+			if length <= len(output) && bytes.HasSuffix(output, []byte(marker[:length])) {
+				longest = length
+				break
+			}
+		}
+	}
+	return longest
+}
+
+func skipLine(output []byte) []byte {
+	lineEnd := bytes.IndexByte(output, '\n')
+	if lineEnd < 0 {
+		return nil
+	}
+	return output[lineEnd+1:]
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
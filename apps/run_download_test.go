@@ -0,0 +1,164 @@
+package apps
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRunDownload(t *testing.T) {
+	download, err := ParseRunDownload("/app/tmp/report.csv:./report.csv")
+	require.NoError(t, err)
+	assert.Equal(t, RunDownload{Remote: "/app/tmp/report.csv", Local: "./report.csv"}, download)
+
+	_, err = ParseRunDownload("/app/tmp/report.csv")
+	assert.Error(t, err)
+	_, err = ParseRunDownload(":report.csv")
+	assert.Error(t, err)
+}
+
+func TestRunDownloads(t *testing.T) {
+	for _, command := range []string{"sh", "tar", "sha256sum", "base64", "mktemp"} {
+		if _, err := exec.LookPath(command); err != nil {
+			t.Skipf("%s is required to run the wrapped command", command)
+		}
+	}
+
+	// run executes the wrapped command locally in remoteDir, the output is given
+	// to the filter in small chunks like on the attach connection
+	run := func(t *testing.T, downloads *runDownloads, remoteDir, cmd string) (string, int) {
+		downloads.progressWriter = io.Discard
+		wrapped := exec.Command("sh", "-c", downloads.wrapCommand(cmd))
+		wrapped.Dir = remoteDir
+		output, err := wrapped.Output()
+		exitCode := 0
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			require.NoError(t, err)
+		}
+
+		filtered := &bytes.Buffer{}
+		filter := downloads.filter(filtered)
+		for len(output) > 0 {
+			chunk := 7
+			if chunk > len(output) {
+				chunk = len(output)
+			}
+			_, err := filter.Write(output[:chunk])
+			require.NoError(t, err)
+			output = output[chunk:]
+		}
+		return filtered.String(), exitCode
+	}
+
+	t.Run("files and directories are downloaded and the output is kept", func(t *testing.T) {
+		remoteDir := t.TempDir()
+		localDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(remoteDir, "tmp", "dumps"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "tmp", "report.csv"), []byte("a,b\n1,2\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "tmp", "dumps", "db.sql"), []byte("SELECT 1;"), 0644))
+
+		downloads, err := newRunDownloads([]RunDownload{
+			{Remote: "tmp/report.csv", Local: filepath.Join(localDir, "report.csv")},
+			{Remote: "tmp/dumps", Local: filepath.Join(localDir, "dumps")},
+		})
+		require.NoError(t, err)
+
+		output, exitCode := run(t, downloads, remoteDir, "echo generating; exit 3")
+		assert.Equal(t, "generating\n", output)
+		assert.Equal(t, 3, exitCode)
+
+		archive, err := downloads.archive()
+		require.NoError(t, err)
+		require.NoError(t, downloads.extract(archive))
+		downloads.cleanup()
+		assert.NoFileExists(t, archive)
+
+		content, err := os.ReadFile(filepath.Join(localDir, "report.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, "a,b\n1,2\n", string(content))
+		content, err = os.ReadFile(filepath.Join(localDir, "dumps", "db.sql"))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT 1;", string(content))
+	})
+
+	t.Run("a missing remote path is reported", func(t *testing.T) {
+		downloads, err := newRunDownloads([]RunDownload{{Remote: "missing.csv", Local: "missing.csv"}})
+		require.NoError(t, err)
+
+		output, _ := run(t, downloads, t.TempDir(), "echo done")
+		assert.Equal(t, "done\n", output)
+
+		_, err = downloads.archive()
+		assert.ErrorContains(t, err, "could not be packed")
+	})
+
+	t.Run("a corrupted archive is detected", func(t *testing.T) {
+		remoteDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "report.csv"), []byte("a,b\n"), 0644))
+		downloads, err := newRunDownloads([]RunDownload{{Remote: "report.csv", Local: "report.csv"}})
+		require.NoError(t, err)
+
+		run(t, downloads, remoteDir, "true")
+		downloads.checksum = "0000"
+
+		_, err = downloads.archive()
+		assert.ErrorContains(t, err, "checksum does not match")
+	})
+	t.Run("the links are reported and not created", func(t *testing.T) {
+		remoteDir := t.TempDir()
+		localDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(remoteDir, "exports"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "exports", "report..csv"), []byte("a,b\n"), 0644))
+		require.NoError(t, os.Symlink("report..csv", filepath.Join(remoteDir, "exports", "latest.csv")))
+		downloads, err := newRunDownloads([]RunDownload{{Remote: "exports", Local: filepath.Join(localDir, "exports")}})
+		require.NoError(t, err)
+		defer downloads.cleanup()
+
+		run(t, downloads, remoteDir, "true")
+		archive, err := downloads.archive()
+		require.NoError(t, err)
+		progress := &bytes.Buffer{}
+		downloads.progressWriter = progress
+		require.NoError(t, downloads.extract(archive))
+
+		assert.FileExists(t, filepath.Join(localDir, "exports", "report..csv"))
+		assert.NoFileExists(t, filepath.Join(localDir, "exports", "latest.csv"))
+		assert.Contains(t, progress.String(), "exports/latest.csv is a symbolic link to report..csv, it has not been downloaded")
+	})
+}
+
+func TestRunDownloads_LocalPath(t *testing.T) {
+	downloads := &runDownloads{downloads: []RunDownload{{Remote: "/app/exports", Local: "exports"}}}
+
+	tests := map[string]struct {
+		name          string
+		expected      string
+		expectedError string
+	}{
+		"with the directory":         {name: "app/exports/", expected: "exports"},
+		"with a file":                {name: "app/exports/report.csv", expected: filepath.Join("exports", "report.csv")},
+		"with dots in the file name": {name: "app/exports/report..csv", expected: filepath.Join("exports", "report..csv")},
+		"with a dots directory name": {name: "app/exports/..old/report.csv", expected: filepath.Join("exports", "..old", "report.csv")},
+		"with a file outside":        {name: "app/exports/../secrets", expectedError: "unexpected file"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			local, err := downloads.localPath(test.name)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, local)
+		})
+	}
+}
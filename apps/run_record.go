@@ -0,0 +1,181 @@
+package apps
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/term"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// maxRunRecordingFileAttempts is the maximal number of sessions of an app
+// recorded during the same second
+const maxRunRecordingFileAttempts = 100
+
+// RunRecordingHeader is the header of a one-off session recorded in the
+// asciicast v2 format. The App, User and Size fields are specific to Scalingo,
+// they are ignored by the other asciicast players.
+type RunRecordingHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	App       string            `json:"app"`
+	User      string            `json:"user"`
+	Size      string            `json:"size"`
+}
+
+// runRecorder writes the output of a one-off session with its timing as
+// asciicast v2 events
+type runRecorder struct {
+	mutex     sync.Mutex
+	writer    stdio.WriteCloser
+	startedAt time.Time
+	err       error
+	// pending is the beginning of a multi-byte character split between two
+	// writes, it is recorded with the next write
+	pending []byte
+}
+
+func newRunRecorder(writer stdio.WriteCloser, header RunRecordingHeader, startedAt time.Time) (*runRecorder, error) {
+	header.Version = 2
+	header.Timestamp = startedAt.Unix()
+	err := json.NewEncoder(writer).Encode(header)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to write the header of the recording")
+	}
+	return &runRecorder{writer: writer, startedAt: startedAt}, nil
+}
+
+// createRunRecording creates the recording of a one-off session of app in the
+// recordings directory
+func createRunRecording(app, size, command string) (*runRecorder, string, error) {
+	err := os.MkdirAll(config.C.RunRecordingsDir, 0700)
+	if err != nil {
+		return nil, "", errgo.Notef(err, "fail to create the recordings directory")
+	}
+
+	startedAt := time.Now()
+	path, fd, err := createRunRecordingFile(app, startedAt)
+	if err != nil {
+		return nil, "", errgo.Notef(err, "fail to create the recording file")
+	}
+
+	header := RunRecordingHeader{
+		Width:   80,
+		Height:  24,
+		Command: command,
+		Title:   fmt.Sprintf("scalingo run on %s", app),
+		Env:     map[string]string{"TERM": os.Getenv("TERM")},
+		App:     app,
+		Size:    size,
+	}
+	if cols, err := term.Cols(); err == nil {
+		header.Width = cols
+	}
+	if lines, err := term.Lines(); err == nil {
+		header.Height = lines
+	}
+	currentUser, err := config.C.CurrentUser()
+	if err == nil && currentUser != nil {
+		header.User = currentUser.Email
+	}
+
+	recorder, err := newRunRecorder(fd, header, startedAt)
+	if err != nil {
+		fd.Close()
+		return nil, "", errgo.Mask(err)
+	}
+	return recorder, path, nil
+}
+
+// createRunRecordingFile creates the file of a recording named after the app
+// and the start of the session. The sessions started during the same second
+// are suffixed by a number.
+func createRunRecordingFile(app string, startedAt time.Time) (string, *os.File, error) {
+	name := fmt.Sprintf("%s-%s", app, startedAt.Format("20060102-150405"))
+	for i := 1; ; i++ {
+		path := filepath.Join(config.C.RunRecordingsDir, name+".cast")
+		if i > 1 {
+			path = filepath.Join(config.C.RunRecordingsDir, fmt.Sprintf("%s-%d.cast", name, i))
+		}
+		fd, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if os.IsExist(err) && i < maxRunRecordingFileAttempts {
+			continue
+		}
+		if err != nil {
+			return "", nil, errgo.Mask(err, errgo.Any)
+		}
+		return path, fd, nil
+	}
+}
+
+// Write records p as an output event. It never fails so that the session is not
+// interrupted by the recording, the first error is returned by Close.
+func (r *runRecorder) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return len(p), nil
+	}
+
+	data := append(r.pending, p...)
+	complete := completeRunesLength(data)
+	r.pending = append([]byte{}, data[complete:]...)
+	if complete > 0 {
+		r.writeEvent(data[:complete])
+	}
+	return len(p), nil
+}
+
+func (r *runRecorder) writeEvent(data []byte) {
+	event := []interface{}{time.Since(r.startedAt).Seconds(), "o", string(data)}
+	r.err = json.NewEncoder(r.writer).Encode(event)
+	if r.err != nil {
+		debug.Println("fail to record the one-off output:", r.err)
+	}
+}
+
+// completeRunesLength returns the length of data without the beginning of a
+// multi-byte character at its end. The invalid UTF-8 sequences are considered
+// complete, they would never be completed by the next writes.
+func completeRunesLength(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if utf8.FullRune(data[i:]) {
+				return len(data)
+			}
+			return i
+		}
+	}
+	return len(data)
+}
+
+func (r *runRecorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.pending) > 0 && r.err == nil {
+		r.writeEvent(r.pending)
+		r.pending = nil
+	}
+	err := r.writer.Close()
+	if r.err != nil {
+		return errgo.Notef(r.err, "fail to write the recording")
+	}
+	if err != nil {
+		return errgo.Notef(err, "fail to close the recording")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package apps
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"os"
+	"time"
+
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+)
+
+type RunReplayOpts struct {
+	File string
+	// Speed multiplies the speed of the replay, 1 by default
+	Speed float64
+	// MaxIdle caps the time between two outputs, no limit if it is 0
+	MaxIdle time.Duration
+}
+
+type runRecordingEvent struct {
+	Time float64
+	Type string
+	Data string
+}
+
+// RunReplay plays back a one-off session recorded with 'run --record'
+func RunReplay(ctx context.Context, opts RunReplayOpts) error {
+	fd, err := os.Open(opts.File)
+	if err != nil {
+		return errgo.Notef(err, "fail to open the recording")
+	}
+	defer fd.Close()
+
+	header, events, err := readRunRecording(fd)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if opts.Speed <= 0 {
+		opts.Speed = 1
+	}
+
+	io.Statusf("One-off session recorded on %s\n", time.Unix(header.Timestamp, 0).Format(time.RFC1123))
+	io.Infof("Command: %s\n", header.Command)
+	io.Infof("User: %s\n", header.User)
+	io.Infof("App: %s\n", header.App)
+	io.Infof("Size: %s\n", header.Size)
+	io.Infof("Terminal: %dx%d\n", header.Width, header.Height)
+	fmt.Println()
+
+	previous := 0.0
+	for _, event := range events {
+		wait := time.Duration((event.Time - previous) / opts.Speed * float64(time.Second))
+		if opts.MaxIdle > 0 && wait > opts.MaxIdle {
+			wait = opts.MaxIdle
+		}
+		previous = event.Time
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if event.Type == "o" {
+			fmt.Print(event.Data)
+		}
+	}
+	fmt.Println()
+	io.Status("End of the recording")
+	return nil
+}
+
+// readRunRecording decodes a recording in the asciicast v2 format: a header
+// line followed by one line per event
+func readRunRecording(reader stdio.Reader) (RunRecordingHeader, []runRecordingEvent, error) {
+	var header RunRecordingHeader
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return header, nil, errgo.New("the recording is empty")
+	}
+	err := json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		return header, nil, errgo.Notef(err, "invalid header")
+	}
+	if header.Version != 2 {
+		return header, nil, errgo.Newf("unsupported asciicast version %d", header.Version)
+	}
+
+	events := []runRecordingEvent{}
+	for line := 2; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var fields []interface{}
+		err := json.Unmarshal(scanner.Bytes(), &fields)
+		if err != nil {
+			return header, nil, errgo.Notef(err, "invalid event on line %d", line)
+		}
+		if len(fields) != 3 {
+			return header, nil, errgo.Newf("invalid event on line %d", line)
+		}
+		eventTime, okTime := fields[0].(float64)
+		eventType, okType := fields[1].(string)
+		data, okData := fields[2].(string)
+		if !okTime || !okType || !okData {
+			return header, nil, errgo.Newf("invalid event on line %d", line)
+		}
+		events = append(events, runRecordingEvent{Time: eventTime, Type: eventType, Data: data})
+	}
+	if err := scanner.Err(); err != nil {
+		return header, nil, errgo.Notef(err, "fail to read the recording")
+	}
+	return header, events, nil
+}
@@ -0,0 +1,117 @@
+package apps
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/cli/config"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error {
+	return nil
+}
+
+func TestRunRecording(t *testing.T) {
+	t.Run("a recorded session is read back", func(t *testing.T) {
+		buffer := &bufferCloser{}
+		startedAt := time.Now()
+		recorder, err := newRunRecorder(buffer, RunRecordingHeader{
+			Width: 120, Height: 40, Command: "bash", App: "my-app", User: "user@example.com", Size: "M",
+		}, startedAt)
+		require.NoError(t, err)
+
+		recorder.Write([]byte("$ ls\r\n"))
+		recorder.Write([]byte("\x1b[32mProcfile\x1b[0m\r\n"))
+		require.NoError(t, recorder.Close())
+
+		header, events, err := readRunRecording(buffer)
+		require.NoError(t, err)
+		assert.Equal(t, 2, header.Version)
+		assert.Equal(t, startedAt.Unix(), header.Timestamp)
+		assert.Equal(t, "bash", header.Command)
+		assert.Equal(t, "my-app", header.App)
+		assert.Equal(t, "user@example.com", header.User)
+		assert.Equal(t, "M", header.Size)
+		require.Len(t, events, 2)
+		assert.Equal(t, "o", events[0].Type)
+		assert.Equal(t, "$ ls\r\n", events[0].Data)
+		assert.Equal(t, "\x1b[32mProcfile\x1b[0m\r\n", events[1].Data)
+		assert.LessOrEqual(t, events[0].Time, events[1].Time)
+	})
+
+	t.Run("a character split between two writes is recorded once complete", func(t *testing.T) {
+		buffer := &bufferCloser{}
+		recorder, err := newRunRecorder(buffer, RunRecordingHeader{Width: 80, Height: 24}, time.Now())
+		require.NoError(t, err)
+
+		euro := []byte("€")
+		recorder.Write([]byte("price: 12 "))
+		recorder.Write(euro[:1])
+		recorder.Write(euro[1:2])
+		recorder.Write(append(euro[2:], []byte("\r\ncaf\xc3")...))
+		require.NoError(t, recorder.Close())
+
+		_, events, err := readRunRecording(buffer)
+		require.NoError(t, err)
+		data := ""
+		for _, event := range events {
+			data += event.Data
+		}
+		assert.Equal(t, "price: 12 €\r\ncaf\uFFFD", data)
+		require.Len(t, events, 3)
+		assert.Equal(t, "€\r\ncaf", events[1].Data)
+	})
+
+	t.Run("the sessions started during the same second have their own file", func(t *testing.T) {
+		recordingsDir := config.C.RunRecordingsDir
+		t.Cleanup(func() {
+			config.C.RunRecordingsDir = recordingsDir
+		})
+		config.C.RunRecordingsDir = t.TempDir()
+
+		startedAt := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local)
+		paths := []string{}
+		for i := 0; i < 3; i++ {
+			path, fd, err := createRunRecordingFile("my-app", startedAt)
+			require.NoError(t, err)
+			fd.Close()
+			paths = append(paths, filepath.Base(path))
+		}
+		assert.Equal(t, []string{"my-app-20240102-150405.cast", "my-app-20240102-150405-2.cast", "my-app-20240102-150405-3.cast"}, paths)
+	})
+
+	tests := map[string]struct {
+		recording     string
+		expectedError string
+	}{
+		"an empty recording": {
+			recording:     "",
+			expectedError: "the recording is empty",
+		},
+		"another version": {
+			recording:     `{"version": 1, "width": 80, "height": 24}`,
+			expectedError: "unsupported asciicast version 1",
+		},
+		"an invalid event": {
+			recording:     "{\"version\": 2, \"width\": 80, \"height\": 24}\n[0.5, \"o\"]\n",
+			expectedError: "invalid event on line 2",
+		},
+	}
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			_, _, err := readRunRecording(strings.NewReader(test.recording))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package apps
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestParseEnvVar(t *testing.T) {
 	ctx := &runContext{}
@@ -19,3 +23,21 @@ func TestParseEnvVarWithEqualSign(t *testing.T) {
 		t.Fatal(env["TEST"], "should be a=b")
 	}
 }
+
+func TestEnvDefined(t *testing.T) {
+	tests := map[string]struct {
+		cmdEnv   []string
+		expected bool
+	}{
+		"without variable":           {cmdEnv: []string{}, expected: false},
+		"with the variable":          {cmdEnv: []string{"FOO=bar", "TERM=xterm"}, expected: true},
+		"with a variable prefixed":   {cmdEnv: []string{"TERMINAL=xterm"}, expected: false},
+		"with the name as the value": {cmdEnv: []string{"FOO=TERM"}, expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, envDefined(test.cmdEnv, "TERM"))
+		})
+	}
+}
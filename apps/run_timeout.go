@@ -0,0 +1,44 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// runTimeout stops the one-off container and closes the connection to it once
+// the timeout is reached
+type runTimeout struct {
+	timer   *time.Timer
+	reached atomic.Bool
+}
+
+func newRunTimeout(ctx context.Context, runCtx *runContext, container *scalingo.Container, socket net.Conn, timeout time.Duration) *runTimeout {
+	t := &runTimeout{}
+	t.timer = time.AfterFunc(timeout, func() {
+		t.reached.Store(true)
+		fmt.Fprintf(runCtx.waitingTextOutputWriter, "\r\n-----> Timeout of %v reached, stopping the one-off %s\r\n", timeout, container.Label)
+		err := runCtx.scalingoClient.ContainersStop(ctx, runCtx.app, container.ID)
+		if err != nil {
+			debug.Println("fail to stop the one-off after the timeout:", err)
+		}
+		socket.Close()
+	})
+	return t
+}
+
+// expired returns true if the one-off has been stopped because of the timeout
+func (t *runTimeout) expired() bool {
+	return t != nil && t.reached.Load()
+}
+
+func (t *runTimeout) stop() {
+	if t != nil {
+		t.timer.Stop()
+	}
+}
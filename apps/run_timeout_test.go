@@ -0,0 +1,91 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	stdio "io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestRunTimeout(t *testing.T) {
+	tests := map[string]struct {
+		timeout          time.Duration
+		stopBefore       bool
+		expectedExpired  bool
+		expectedRequests []string
+	}{
+		"when the timeout is reached": {
+			timeout:          10 * time.Millisecond,
+			expectedExpired:  true,
+			expectedRequests: []string{"POST /v1/apps/my-app/containers/container-id/stop"},
+		},
+		"when the one-off is done before": {
+			timeout:          50 * time.Millisecond,
+			stopBefore:       true,
+			expectedExpired:  false,
+			expectedRequests: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var mutex sync.Mutex
+			requests := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			c, err := scalingo.New(ctx, scalingo.ClientConfig{
+				APIEndpoint:          server.URL,
+				StaticTokenGenerator: scalingo.NewStaticTokenGenerator("token"),
+			})
+			require.NoError(t, err)
+
+			runCtx := &runContext{app: "my-app", scalingoClient: c, waitingTextOutputWriter: new(bytes.Buffer)}
+			socket, remote := net.Pipe()
+			defer remote.Close()
+
+			timeout := newRunTimeout(ctx, runCtx, &scalingo.Container{ID: "container-id", Label: "one-off-1"}, socket, test.timeout)
+			if test.stopBefore {
+				timeout.stop()
+			}
+
+			// The connection is closed once the one-off is stopped
+			remote.SetReadDeadline(time.Now().Add(2 * test.timeout))
+			_, err = remote.Read(make([]byte, 1))
+			if test.expectedExpired {
+				assert.Equal(t, stdio.EOF, err)
+			} else {
+				assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+			}
+
+			assert.Equal(t, test.expectedExpired, timeout.expired())
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+
+	t.Run("without timeout", func(t *testing.T) {
+		var timeout *runTimeout
+		assert.False(t, timeout.expired())
+		timeout.stop()
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/containersizes"
 	"github.com/Scalingo/cli/io"
 	"github.com/Scalingo/cli/utils"
 	"github.com/Scalingo/go-scalingo/v6"
@@ -23,7 +24,18 @@ type ScaleRes struct {
 	Containers []scalingo.ContainerType `json:"containers"`
 }
 
-func Scale(ctx context.Context, app string, sync bool, types []string) error {
+type ScaleOpts struct {
+	// Sync waits for the end of the scaling operation
+	Sync bool
+	// Async only prints the ID of the scaling operation, to be waited later
+	// with 'operation-wait'
+	Async bool
+	// DryRun only prints the scaling parameters which would be sent, with the
+	// changes of the formation
+	DryRun bool
+}
+
+func Scale(ctx context.Context, app string, opts ScaleOpts, types []string) error {
 	var (
 		size           string
 		containerTypes []scalingo.ContainerType
@@ -98,6 +110,31 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		scaleParams.Containers = append(scaleParams.Containers, newContainerConfig)
 	}
 
+	sizes := []string{}
+	for _, container := range scaleParams.Containers {
+		if container.Size != "" {
+			sizes = append(sizes, container.Size)
+		}
+	}
+	// The container sizes are listed once, to validate the sizes and to
+	// estimate the cost of the dry run
+	var containerSizes []scalingo.ContainerSize
+	if len(sizes) > 0 || opts.DryRun {
+		containerSizes, err = c.ContainerSizesList(ctx)
+		if err != nil {
+			debug.Println("fail to list the container sizes:", err)
+		} else {
+			err = containersizes.ValidateWithList(containerSizes, sizes...)
+			if err != nil {
+				return errgo.Mask(err)
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return scaleDryRun(ctx, c, app, scaleParams, typesWithAutoscaler, containerSizes)
+	}
+
 	if len(typesWithAutoscaler) > 0 {
 		io.Warning(autoscaleDisableMessage(typesWithAutoscaler))
 
@@ -125,7 +162,7 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		}
 		// If error is Payment Required and user tries to exceed its free trial
 		return utils.AskAndStopFreeTrial(ctx, c, func() error {
-			return Scale(ctx, app, sync, types)
+			return Scale(ctx, app, opts, types)
 		})
 	}
 	defer res.Body.Close()
@@ -136,12 +173,23 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		return errgo.Notef(err, "fail to decode API response to scale operation")
 	}
 
+	operationID := OperationIDFromHTTPResponse(res)
+	trackOperation(app, operationID, scalingo.OperationTypeScale)
+	if opts.Async {
+		if operationID == "" {
+			return errNoOperationID
+		}
+		fmt.Println(operationID)
+		return nil
+	}
+
 	fmt.Printf("Your application is being scaled to:\n")
 	for _, ct := range scaleRes.Containers {
 		fmt.Println(io.Indent(fmt.Sprintf("%s: %d - %s", ct.Name, ct.Amount, ct.Size), 2))
 	}
 
-	if !sync {
+	if !opts.Sync {
+		displayOperationID(app, operationID)
 		return nil
 	}
 
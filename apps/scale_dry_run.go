@@ -0,0 +1,134 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/costs"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// scaleChange is the change of a container type of the formation
+type scaleChange struct {
+	Name          string
+	CurrentAmount int
+	NewAmount     int
+	CurrentSize   string
+	NewSize       string
+}
+
+// SizeChanged returns true if the size of the containers changes, which
+// impacts the cost of the application
+func (c scaleChange) SizeChanged() bool {
+	return c.CurrentSize != "" && c.NewSize != c.CurrentSize
+}
+
+// scaleDryRun prints the parameters of the scaling request and the changes of
+// the formation without scaling the application. The cost is estimated with
+// the container sizes, it is not estimated if they could not be listed.
+func scaleDryRun(ctx context.Context, c *scalingo.Client, app string, params *scalingo.AppsScaleParams, typesWithAutoscaler []string, containerSizes []scalingo.ContainerSize) error {
+	current, err := c.AppsContainerTypes(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current formation")
+	}
+
+	content, err := json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the scaling parameters")
+	}
+	io.Status("Dry run, the application is not scaled")
+	io.Info("Parameters which would be sent to the Scalingo API:")
+	fmt.Println(io.Indent(string(content), 7))
+	fmt.Println()
+
+	changes := scaleChanges(current, params.Containers)
+	sizeChanged := []string{}
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Type", "Amount", "Size"})
+	for _, change := range changes {
+		amount := strconv.Itoa(change.NewAmount)
+		if change.NewAmount != change.CurrentAmount {
+			amount = fmt.Sprintf("%d → %d", change.CurrentAmount, change.NewAmount)
+		}
+		size := change.NewSize
+		if change.SizeChanged() {
+			size = io.Yellow(fmt.Sprintf("%s → %s (!)", change.CurrentSize, change.NewSize))
+			sizeChanged = append(sizeChanged, change.Name)
+		}
+		t.Append([]string{change.Name, amount, size})
+	}
+	t.Render()
+
+	if len(sizeChanged) > 0 {
+		io.Warningf("The size change of %s impacts the cost of the application\n", strings.Join(sizeChanged, ", "))
+	}
+
+	if containerSizes == nil {
+		io.Warning("The monthly cost cannot be estimated: the container sizes could not be listed")
+	} else {
+		prices := costs.NewPricesFromSizes(c, containerSizes)
+		before, unknownBefore := costs.FormationCost(prices, current)
+		after, unknownAfter := costs.FormationCost(prices, scaledFormation(current, changes))
+		io.Infof("Monthly cost of the formation: %s\n", costs.FormatPriceChange(before, after))
+		if len(unknownBefore) > 0 || len(unknownAfter) > 0 {
+			io.Warningf("The price of the sizes %s is unknown\n", strings.Join(append(unknownBefore, unknownAfter...), ", "))
+		}
+	}
+
+	if len(typesWithAutoscaler) > 0 {
+		io.Warningf("The autoscaler of %s would be disabled\n", strings.Join(typesWithAutoscaler, ", "))
+	}
+	return nil
+}
+
+// scaledFormation returns the formation once the changes are applied
+func scaledFormation(current []scalingo.ContainerType, changes []scaleChange) []scalingo.ContainerType {
+	formation := []scalingo.ContainerType{}
+	for _, ct := range current {
+		formation = append(formation, scalingo.ContainerType{Name: ct.Name, Amount: ct.Amount, Size: ct.Size})
+	}
+	for _, change := range changes {
+		found := false
+		for i := range formation {
+			if formation[i].Name == change.Name {
+				formation[i].Amount = change.NewAmount
+				formation[i].Size = change.NewSize
+				found = true
+				break
+			}
+		}
+		if !found {
+			formation = append(formation, scalingo.ContainerType{Name: change.Name, Amount: change.NewAmount, Size: change.NewSize})
+		}
+	}
+	return formation
+}
+
+// scaleChanges returns the changes of the container types scaled from the
+// current formation to the new one. The size is kept when it is not given.
+func scaleChanges(current []scalingo.ContainerType, containers []scalingo.ContainerType) []scaleChange {
+	changes := []scaleChange{}
+	for _, container := range containers {
+		change := scaleChange{Name: container.Name, NewAmount: container.Amount, NewSize: container.Size}
+		for _, ct := range current {
+			if ct.Name == container.Name {
+				change.CurrentAmount = ct.Amount
+				change.CurrentSize = ct.Size
+				break
+			}
+		}
+		if change.NewSize == "" {
+			change.NewSize = change.CurrentSize
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
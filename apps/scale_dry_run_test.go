@@ -0,0 +1,50 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestScaleChanges(t *testing.T) {
+	current := []scalingo.ContainerType{
+		{Name: "web", Amount: 2, Size: "M"},
+		{Name: "worker", Amount: 1, Size: "S"},
+	}
+
+	changes := scaleChanges(current, []scalingo.ContainerType{
+		{Name: "web", Amount: 4},
+		{Name: "worker", Amount: 1, Size: "L"},
+		{Name: "clock", Amount: 1, Size: "S"},
+	})
+
+	assert.Equal(t, []scaleChange{
+		{Name: "web", CurrentAmount: 2, NewAmount: 4, CurrentSize: "M", NewSize: "M"},
+		{Name: "worker", CurrentAmount: 1, NewAmount: 1, CurrentSize: "S", NewSize: "L"},
+		{Name: "clock", CurrentAmount: 0, NewAmount: 1, CurrentSize: "", NewSize: "S"},
+	}, changes)
+	assert.False(t, changes[0].SizeChanged())
+	assert.True(t, changes[1].SizeChanged())
+	assert.False(t, changes[2].SizeChanged())
+}
+
+func TestScaledFormation(t *testing.T) {
+	current := []scalingo.ContainerType{
+		{Name: "web", Amount: 2, Size: "M", Command: "bundle exec puma"},
+		{Name: "worker", Amount: 1, Size: "S"},
+	}
+
+	formation := scaledFormation(current, []scaleChange{
+		{Name: "web", CurrentAmount: 2, NewAmount: 3, CurrentSize: "M", NewSize: "L"},
+		{Name: "clock", NewAmount: 1, NewSize: "S"},
+	})
+
+	assert.Equal(t, []scalingo.ContainerType{
+		{Name: "web", Amount: 3, Size: "L"},
+		{Name: "worker", Amount: 1, Size: "S"},
+		{Name: "clock", Amount: 1, Size: "S"},
+	}, formation)
+	assert.Equal(t, 2, current[0].Amount)
+}
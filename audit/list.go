@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/utils"
+)
+
+type ListOpts struct {
+	App     string
+	Command string
+	Outcome Outcome
+	Since   time.Time
+	JSON    bool
+}
+
+func (opts ListOpts) match(record Record) bool {
+	if opts.App != "" && record.App != opts.App {
+		return false
+	}
+	if opts.Command != "" && record.Command != opts.Command {
+		return false
+	}
+	if opts.Outcome != "" && record.Outcome != opts.Outcome {
+		return false
+	}
+	if !opts.Since.IsZero() && record.Time.Before(opts.Since) {
+		return false
+	}
+	return true
+}
+
+func List(opts ListOpts) error {
+	records, err := Records()
+	if err != nil {
+		return errgo.Notef(err, "fail to read the audit trail")
+	}
+
+	matching := []Record{}
+	for _, record := range records {
+		if opts.match(record) {
+			matching = append(matching, record)
+		}
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, record := range matching {
+			err := encoder.Encode(record)
+			if err != nil {
+				return errgo.Notef(err, "fail to encode the audit record")
+			}
+		}
+		return nil
+	}
+
+	if len(matching) == 0 {
+		fmt.Println("No audit record found.")
+		return nil
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Time", "User", "Region", "App", "Command", "Arguments", "Outcome"})
+	for _, record := range matching {
+		t.Append([]string{
+			record.Time.Local().Format(utils.TimeFormat),
+			record.User,
+			record.Region,
+			record.App,
+			record.Command,
+			strings.Join(record.Arguments, " "),
+			string(record.Outcome),
+		})
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// Append writes the record at the end of the audit trail and forwards it to
+// the audit hook if one is configured. A failure of the hook is not reported
+// to the caller, it only ends up in the debug output and the log file.
+func Append(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the audit record")
+	}
+	line = append(line, '\n')
+
+	fd, err := os.OpenFile(config.C.AuditLogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to open the audit log file")
+	}
+	defer fd.Close()
+
+	_, err = fd.Write(line)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the audit record")
+	}
+
+	if config.C.AuditHook != "" {
+		err = runHook(config.C.AuditHook, line)
+		if err != nil {
+			debug.Println("[Audit] Fail to run the audit hook:", err)
+			config.C.Logger.Println("audit hook failed:", err)
+		}
+	}
+	return nil
+}
+
+// runHook executes the hook command in a shell, the record is given on its
+// standard input
+func runHook(hook string, line []byte) error {
+	cmd := utils.ShellCommand(hook)
+	cmd.Stdin = bytes.NewReader(line)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errgo.Notef(err, "hook '%s' output: %s", hook, output)
+	}
+	return nil
+}
+
+// Records returns all the records of the audit trail, oldest first. A
+// missing audit trail is not an error.
+func Records() ([]Record, error) {
+	fd, err := os.Open(config.C.AuditLogFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to open the audit log file")
+	}
+	defer fd.Close()
+
+	records := []Record{}
+	scanner := bufio.NewScanner(fd)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record Record
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			debug.Println("[Audit] Skip malformed record:", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errgo.Notef(err, "fail to read the audit log file")
+	}
+	return records, nil
+}
@@ -0,0 +1,169 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/costs"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+	httpclient "github.com/Scalingo/go-scalingo/v6/http"
+)
+
+// Invoices lists the invoices of the account, most recent first
+func Invoices(ctx context.Context, paginationOpts scalingo.PaginationOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	invoices, pagination, err := c.InvoicesList(ctx, paginationOpts)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the invoices")
+	}
+	if len(invoices) == 0 {
+		io.Status("No invoice")
+		return nil
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"ID", "Number", "Month", "Total", "Total With VAT", "State"})
+	for _, invoice := range invoices {
+		t.Append([]string{
+			invoice.ID,
+			invoice.InvoiceNumber,
+			billingMonth(invoice),
+			costs.FormatPrice(invoice.TotalPrice),
+			costs.FormatPrice(invoice.TotalPriceWithVat),
+			invoice.State,
+		})
+	}
+	t.Render()
+	fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
+	return nil
+}
+
+// InvoiceShow displays an invoice with its items
+func InvoiceShow(ctx context.Context, id string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	invoice, err := c.InvoiceShow(ctx, id)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the invoice")
+	}
+
+	io.Statusf("Invoice %s of %s\n", io.Bold(invoice.InvoiceNumber), billingMonth(invoice))
+	io.Infof("ID: %s\n", invoice.ID)
+	io.Infof("State: %s\n", invoice.State)
+	io.Infof("Total: %s\n", costs.FormatPrice(invoice.TotalPrice))
+	io.Infof("Total with VAT (%d%%): %s\n", invoice.VatRate, costs.FormatPrice(invoice.TotalPriceWithVat))
+	fmt.Println()
+
+	if len(invoice.DetailedItems) > 0 {
+		t := tablewriter.NewWriter(os.Stdout)
+		t.SetHeader([]string{"App", "Item", "Price"})
+		for _, item := range invoice.DetailedItems {
+			t.Append([]string{item.App, item.Label, costs.FormatPrice(item.Price)})
+		}
+		t.Render()
+	} else {
+		t := tablewriter.NewWriter(os.Stdout)
+		t.SetHeader([]string{"Item", "Price"})
+		for _, item := range invoice.Items {
+			t.Append([]string{item.Label, costs.FormatPrice(item.Price)})
+		}
+		t.Render()
+	}
+
+	if invoice.PdfURL != "" {
+		fmt.Println()
+		io.Infof("Download the PDF with 'scalingo invoice-download %s'\n", invoice.ID)
+	}
+	return nil
+}
+
+// InvoiceDownload writes the PDF of the invoice to output. The PDF is named
+// after the invoice number by default, or in the output directory if it is
+// one.
+func InvoiceDownload(ctx context.Context, id, output string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	invoice, err := c.InvoiceShow(ctx, id)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the invoice")
+	}
+	if invoice.PdfURL == "" {
+		return errgo.Newf("the PDF of the invoice %s is not available yet", id)
+	}
+
+	path := invoicePDFPath(invoice, output)
+
+	res, err := http.Get(invoice.PdfURL)
+	if err != nil {
+		return errgo.Notef(err, "fail to start the download")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return httpclient.NewRequestFailedError(res, &httpclient.APIRequest{
+			URL:    invoice.PdfURL,
+			Method: "GET",
+		})
+	}
+
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to create %s", path)
+	}
+	defer fd.Close()
+
+	bar := pb.New64(res.ContentLength).Set(pb.Bytes, true).SetWriter(os.Stderr)
+	bar.Start()
+	_, err = stdio.Copy(fd, bar.NewProxyReader(res.Body))
+	bar.Finish()
+	if err != nil {
+		return errgo.Notef(err, "fail to download the PDF")
+	}
+
+	io.Statusf("Invoice %s downloaded to %s\n", invoice.InvoiceNumber, path)
+	return nil
+}
+
+func invoicePDFPath(invoice *scalingo.Invoice, output string) string {
+	name := invoice.InvoiceNumber
+	if name == "" {
+		name = invoice.ID
+	}
+	name += ".pdf"
+
+	if output == "" {
+		return name
+	}
+	if stat, err := os.Stat(output); err == nil && stat.IsDir() {
+		return filepath.Join(output, name)
+	}
+	return output
+}
+
+func billingMonth(invoice *scalingo.Invoice) string {
+	month := time.Time(invoice.BillingMonth)
+	if month.IsZero() {
+		return ""
+	}
+	return month.Format("January 2006")
+}
@@ -0,0 +1,45 @@
+package billing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestInvoicePDFPath(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := map[string]struct {
+		invoice  *scalingo.Invoice
+		output   string
+		expected string
+	}{
+		"without output": {
+			invoice:  &scalingo.Invoice{ID: "inv-1", InvoiceNumber: "2023-0042"},
+			expected: "2023-0042.pdf",
+		},
+		"without invoice number": {
+			invoice:  &scalingo.Invoice{ID: "inv-1"},
+			expected: "inv-1.pdf",
+		},
+		"with an output file": {
+			invoice:  &scalingo.Invoice{ID: "inv-1", InvoiceNumber: "2023-0042"},
+			output:   "invoice.pdf",
+			expected: "invoice.pdf",
+		},
+		"with an output directory": {
+			invoice:  &scalingo.Invoice{ID: "inv-1", InvoiceNumber: "2023-0042"},
+			output:   dir,
+			expected: filepath.Join(dir, "2023-0042.pdf"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, invoicePDFPath(test.invoice, test.output))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package billing
+
+import (
+	"context"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	scalingobilling "github.com/Scalingo/go-scalingo/v6/billing"
+)
+
+// Profile displays the billing profile of the account. The go-scalingo client
+// has the type of the billing profile but no method to get it, it is requested
+// directly.
+func Profile(ctx context.Context) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	var res struct {
+		Profile scalingobilling.Profile `json:"profile"`
+	}
+	err = c.ScalingoAPI().ResourceGet(ctx, "account", "profile", nil, &res)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the billing profile")
+	}
+
+	profile := res.Profile
+	io.Status("Billing profile")
+	io.Infof("Company: %s\n", valueOrNone(profile.Company))
+	io.Infof("VAT number: %s\n", valueOrNone(profile.VATNumber))
+	io.Infof("Payment method: %s\n", paymentMethod(profile))
+	return nil
+}
+
+func paymentMethod(profile scalingobilling.Profile) string {
+	switch profile.PaymentMethodType {
+	case scalingobilling.Stripe:
+		return "Card " + profile.Stripe.Brand + " **** " + profile.Stripe.Last4 + " (expires " + profile.Stripe.Exp + ")"
+	case scalingobilling.Paypal:
+		return "PayPal"
+	default:
+		return valueOrNone(string(profile.PaymentMethodType))
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
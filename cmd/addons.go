@@ -102,15 +102,21 @@ var (
 		},
 	}
 	addonsUpgradeCommand = cli.Command{
-		Name:      "addons-upgrade",
-		Category:  "Addons",
-		Flags:     []cli.Flag{&appFlag},
+		Name:     "addons-upgrade",
+		Category: "Addons",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only print the monthly cost before and after the upgrade, without upgrading"},
+		},
 		Usage:     "Upgrade or downgrade an add-on attached to your app",
 		ArgsUsage: "addon-id plan",
 		Description: CommandDescription{
-			Description: "Upgrade an addon attached to your app",
-			Examples:    []string{"scalingo --app my-app addons-upgrade addon_uuid mongo-starter-256"},
-			SeeAlso:     []string{"addons-plans", "addons-remove"},
+			Description: "Upgrade an addon attached to your app. The addon is designated by its ID, or by its provider if the app has a single addon of this provider.",
+			Examples: []string{
+				"scalingo --app my-app addons-upgrade addon_uuid mongo-starter-256",
+				"scalingo addons-upgrade --apps-match 'prefix-*' postgresql postgresql-starter-1024",
+				"scalingo --app my-app addons-upgrade --dry-run postgresql postgresql-business-1024",
+			},
+			SeeAlso: []string{"addons-plans", "addons-remove", "costs"},
 		}.Render(),
 
 		Action: func(c *cli.Context) error {
@@ -119,7 +125,9 @@ var (
 				return cli.ShowCommandHelp(c, "addons-upgrade")
 			}
 
-			err := addons.Upgrade(c.Context, currentApp, c.Args().First(), c.Args().Slice()[1])
+			err := addons.Upgrade(c.Context, currentApp, c.Args().First(), c.Args().Slice()[1], addons.UpgradeOpts{
+				DryRun: c.Bool("dry-run"),
+			})
 			if err != nil {
 				errorQuit(err)
 			}
@@ -10,12 +10,34 @@ import (
 
 var (
 	appsCommand = cli.Command{
-		Name:        "apps",
-		Category:    "Global",
-		Description: "List your apps and give some details about them",
-		Usage:       "List your apps",
+		Name:     "apps",
+		Category: "Global",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "all-regions", Usage: "List the apps of all the regions"},
+			&cli.StringFlag{Name: "owner", Usage: "Only list the apps owned by this user (email or username)"},
+			&cli.StringFlag{Name: "stack", Usage: "Only list the apps using this stack"},
+			&cli.StringFlag{Name: "status", Usage: "Only list the apps with this status (new, running, stopped, scaling or restarting)"},
+		},
+		Description: CommandDescription{
+			Description: `List your apps and give some details about them.
+With --all-regions, the apps of all the regions are fetched and a Region column is added. A region which cannot be reached is skipped with a warning.`,
+			Examples: []string{
+				"scalingo apps",
+				"scalingo apps --all-regions --status stopped",
+				"scalingo apps --owner user@example.com --stack scalingo-22",
+			},
+		}.Render(),
+		Usage: "List your apps",
 		Action: func(c *cli.Context) error {
-			if err := apps.List(c.Context); err != nil {
+			err := apps.List(c.Context, apps.ListOpts{
+				AllRegions: c.Bool("all-regions"),
+				Filter: apps.ListFilter{
+					Owner:  c.String("owner"),
+					Stack:  c.String("stack"),
+					Status: c.String("status"),
+				},
+			})
+			if err != nil {
 				errorQuit(err)
 			}
 			return nil
@@ -46,4 +68,54 @@ var (
 			autocomplete.CmdFlagsAutoComplete(c, "apps-info")
 		},
 	}
+
+	appsCloneCommand = cli.Command{
+		Name:     "apps-clone",
+		Category: "App Management",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "region", Aliases: []string{"to"}, Usage: "Region of the new application, the region of the source application by default"},
+			&cli.BoolFlag{Name: "with-addons", Usage: "Provision the addons of the source application with the same plans"},
+			&cli.BoolFlag{Name: "with-collaborators", Usage: "Invite the collaborators of the source application"},
+			&cli.BoolFlag{Name: "with-env", Usage: "Copy the environment variables of the source application"},
+		},
+		Usage:     "Create a new application with the configuration of an existing one",
+		ArgsUsage: "source-app target-app",
+		Description: CommandDescription{
+			Description: `Create a new application, in the current region or in another one, with the configuration of an existing application.
+The stack and the force HTTPS, sticky session and router logs settings are always copied.
+The formation cannot be applied before the first deployment of the new application, the scale command to run after this deployment is printed at the end.
+The addons plans, the environment variables and the collaborators are copied with the corresponding flags.
+The environment variables already defined by the addons of the new application are not overwritten, and the ones generated by the addons of the source application are never copied.
+
+The source application is looked up in the region given before the command name, the new application is created in the region given with the --region flag of the command (or its --to alias).
+The data of the addons, the domains and the certificates are never copied. The elements which could not be copied are listed at the end.`,
+			Examples: []string{
+				"scalingo apps-clone my-app my-app-staging --with-addons --with-env",
+				"scalingo --region osc-fr1 apps-clone my-app my-app --region osc-secnum-fr1 --with-addons --with-env --with-collaborators",
+			},
+			SeeAlso: []string{"create", "migration-create"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 2 {
+				cli.ShowCommandHelp(c, "apps-clone")
+				return nil
+			}
+
+			err := apps.Clone(c.Context, apps.CloneOpts{
+				Source:            c.Args().Get(0),
+				Target:            c.Args().Get(1),
+				TargetRegion:      c.String("region"),
+				WithAddons:        c.Bool("with-addons"),
+				WithCollaborators: c.Bool("with-collaborators"),
+				WithEnv:           c.Bool("with-env"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "apps-clone")
+		},
+	}
 )
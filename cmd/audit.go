@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/audit"
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// auditedCommands are the commands modifying resources. Their execution is
+// recorded in the audit trail.
+var auditedCommands = map[string]bool{
+	"create": true, "destroy": true, "rename": true, "apps-clone": true,
+	"run": true, "task": true, "one-off-stop": true,
+	"scale": true, "restart": true, "send-signal": true,
+	"force-https": true, "sticky-session": true, "router-logs": true,
+	"set-canonical-domain": true, "unset-canonical-domain": true,
+	"env-set": true, "env-unset": true,
+	"domains-add": true, "domains-remove": true, "domains-ssl": true, "domains-import": true,
+	"deploy": true, "deployment-delete-cache": true,
+	"collaborators-add": true, "collaborators-remove": true,
+	"stacks-set": true,
+	"addons-add": true, "addons-remove": true, "addons-upgrade": true,
+	"integration-link-create": true, "integration-link-update": true, "integration-link-delete": true,
+	"integration-link-manual-deploy": true, "integration-link-manual-review-app": true, "review-apps-destroy": true,
+	"review-app-create": true, "review-app-destroy": true,
+	"notifiers-add": true, "notifiers-update": true, "notifiers-remove": true,
+	"database-enable-feature": true, "database-disable-feature": true, "backups-config": true,
+	"backups-create": true,
+	"alerts-add":     true, "alerts-update": true, "alerts-enable": true, "alerts-disable": true, "alerts-remove": true,
+	"autoscalers-add": true, "autoscalers-update": true, "autoscalers-enable": true, "autoscalers-disable": true, "autoscalers-remove": true,
+	"migration-create": true, "migration-run": true, "migration-abort": true,
+	"log-drains-add": true, "log-drains-remove": true,
+	"keys-add": true, "keys-remove": true,
+	"integrations-add": true, "integrations-delete": true, "integrations-import-keys": true,
+}
+
+// pendingAuditRecord is the record of the audited command being executed. It
+// is completed when the command returns or when it exits through errorQuit.
+var pendingAuditRecord *audit.Record
+
+func auditedCommandAction(action cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		// A dry run does not modify any resource, it is not recorded
+		if c.Bool("dry-run") {
+			return action(c)
+		}
+
+		requestID := newRequestID()
+		// All the requests to the Scalingo API share the same request ID so
+		// that the audit record can be matched with the API logs.
+		c.Context = context.WithValue(c.Context, "request_id", requestID)
+
+		record := &audit.Record{
+			Time:      time.Now(),
+			App:       detect.CurrentAppName(c),
+			Region:    config.C.ScalingoRegion,
+			Command:   c.Command.Name,
+			Arguments: audit.RedactArguments(os.Args[1:]),
+			RequestID: requestID,
+		}
+		currentUser, err := config.C.CurrentUser()
+		if err == nil && currentUser != nil {
+			record.User = currentUser.Email
+		}
+		pendingAuditRecord = record
+
+		err = action(c)
+		completeAuditRecord(err)
+		return err
+	}
+}
+
+// completeAuditRecord writes the pending audit record with the outcome of the
+// command, it does nothing if the current command is not audited
+func completeAuditRecord(err error) {
+	record := pendingAuditRecord
+	if record == nil {
+		return
+	}
+	pendingAuditRecord = nil
+
+	record.Duration = time.Since(record.Time)
+	record.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+	}
+
+	auditErr := audit.Append(*record)
+	if auditErr != nil {
+		debug.Println("[Audit] Fail to record the command:", auditErr)
+		config.C.Logger.Println("fail to write the audit record:", auditErr)
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+var (
+	auditLogCommand = cli.Command{
+		Name:     "audit-log",
+		Category: "Global",
+		Usage:    "Query the local audit trail of the commands modifying your resources",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "app", Aliases: []string{"a"}, Usage: "Only display the records of this app"},
+			&cli.StringFlag{Name: "command", Aliases: []string{"c"}, Usage: "Only display the records of this command"},
+			&cli.StringFlag{Name: "outcome", Usage: "Only display the records with this outcome (success or failure)"},
+			&cli.DurationFlag{Name: "since", Usage: "Only display the records of this last period of time (e.g. 24h)"},
+			&cli.BoolFlag{Name: "json", Usage: "Output the records as JSON Lines"},
+		},
+		Description: CommandDescription{
+			Description: `Query the audit trail of the commands modifying your resources (scale, env-set, addons-add, destroy...).
+
+Each record contains the date, the user, the app, the region, the command with its arguments (secrets are redacted), the outcome and the request ID sent to the Scalingo API.
+
+The records are written in the file defined by the AUDIT_LOG_FILE environment variable (default: audit.log in the configuration directory).
+Each record can also be forwarded to a command receiving it as JSON on its standard input, configured with 'scalingo config --audit-hook' or the SCALINGO_AUDIT_HOOK environment variable.`,
+			Examples: []string{
+				"scalingo audit-log",
+				"scalingo audit-log --app my-app --since 720h",
+				"scalingo audit-log --command env-set --outcome failure",
+				"scalingo audit-log --json > audit.jsonl",
+			},
+			SeeAlso: []string{"config"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				return cli.ShowCommandHelp(c, "audit-log")
+			}
+
+			opts := audit.ListOpts{
+				App:     c.String("app"),
+				Command: c.String("command"),
+				Outcome: audit.Outcome(c.String("outcome")),
+				JSON:    c.Bool("json"),
+			}
+			switch opts.Outcome {
+			case "", audit.OutcomeSuccess, audit.OutcomeFailure:
+			default:
+				errorQuit(errgo.Newf("invalid outcome '%s', must be %s or %s", opts.Outcome, audit.OutcomeSuccess, audit.OutcomeFailure))
+			}
+			if c.Duration("since") > 0 {
+				opts.Since = time.Now().Add(-c.Duration("since"))
+			}
+
+			err := audit.List(opts)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+	}
+)
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/audit"
+	"github.com/Scalingo/cli/config"
+)
+
+func TestAuditedCommandAction_Exit(t *testing.T) {
+	tests := map[string]struct {
+		exitCode        int
+		expectedOutcome audit.Outcome
+		expectedError   string
+	}{
+		"with a successful one-off": {
+			exitCode:        0,
+			expectedOutcome: audit.OutcomeSuccess,
+		},
+		"with a failed one-off": {
+			exitCode:        3,
+			expectedOutcome: audit.OutcomeFailure,
+			expectedError:   "the one-off exited with code 3",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			auditLogFile := config.C.AuditLogFile
+			auditHook := config.C.AuditHook
+			t.Cleanup(func() {
+				config.C.AuditLogFile = auditLogFile
+				config.C.AuditHook = auditHook
+				osExit = os.Exit
+			})
+			config.C.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+			config.C.AuditHook = ""
+
+			exitCodes := []int{}
+			osExit = func(code int) {
+				// The records must be written before the process exits
+				records, err := audit.Records()
+				require.NoError(t, err)
+				require.Len(t, records, 1)
+				exitCodes = append(exitCodes, code)
+			}
+
+			app := cli.NewApp()
+			app.Commands = []*cli.Command{{
+				Name: "run",
+				Action: auditedCommandAction(func(c *cli.Context) error {
+					exitWithCode(test.exitCode)
+					return nil
+				}),
+			}}
+			err := app.Run([]string{"scalingo", "run"})
+			require.NoError(t, err)
+
+			assert.Equal(t, []int{test.exitCode}, exitCodes)
+			records, err := audit.Records()
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			assert.Equal(t, "run", records[0].Command)
+			assert.Equal(t, test.expectedOutcome, records[0].Outcome)
+			assert.Equal(t, test.expectedError, records[0].Error)
+		})
+	}
+}
+
+func TestAuditedCommandAction_DryRun(t *testing.T) {
+	auditLogFile := config.C.AuditLogFile
+	auditHook := config.C.AuditHook
+	t.Cleanup(func() {
+		config.C.AuditLogFile = auditLogFile
+		config.C.AuditHook = auditHook
+	})
+	config.C.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+	config.C.AuditHook = ""
+
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{{
+		Name:  "scale",
+		Flags: []cli.Flag{&cli.BoolFlag{Name: "dry-run"}},
+		Action: auditedCommandAction(func(c *cli.Context) error {
+			return nil
+		}),
+	}}
+	require.NoError(t, app.Run([]string{"scalingo", "scale", "--dry-run"}))
+	records, err := audit.Records()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, app.Run([]string{"scalingo", "scale"}))
+	records, err = audit.Records()
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
@@ -83,13 +83,48 @@ func DisplayFlags(flags []cli.Flag) {
 	}
 }
 
+// flagCompleter completes the value of a flag which can be given with any of
+// the names listed in flags (e.g. "--app" and "-a").
+type flagCompleter struct {
+	flags    []string
+	complete func(c *cli.Context) bool
+}
+
+// flagCompleters is the list of flags whose values are dynamically completed.
+// It is used both when completing a command line and when generating the
+// completion scripts of the shells.
+var flagCompleters = []flagCompleter{
+	{flags: []string{"-r", "--remote"}, complete: FlagRemoteAutoComplete},
+	{flags: []string{"-a", "--app"}, complete: FlagAppAutoComplete},
+}
+
+func findFlagCompleter(flag string) (flagCompleter, bool) {
+	for _, completer := range flagCompleters {
+		for _, name := range completer.flags {
+			if name == flag {
+				return completer, true
+			}
+		}
+	}
+	return flagCompleter{}, false
+}
+
+// HasFlagCompleter returns true if the values of the flag with the given name
+// (without leading dashes) are dynamically completed.
+func HasFlagCompleter(name string) bool {
+	prefix := "--"
+	if len(name) == 1 {
+		prefix = "-"
+	}
+	_, ok := findFlagCompleter(prefix + name)
+	return ok
+}
+
 func FlagsAutoComplete(c *cli.Context, flag string) bool {
-	switch flag {
-	case "-r", "--remote":
-		return CountFlags([]string{"-r", "--remote"}) == 1 && FlagRemoteAutoComplete(c)
-	case "-a", "--app":
-		return CountFlags([]string{"-a", "--app"}) == 1 && FlagAppAutoComplete(c)
+	completer, ok := findFlagCompleter(flag)
+	if !ok {
+		return false
 	}
 
-	return false
+	return CountFlags(completer.flags) == 1 && completer.complete(c)
 }
@@ -0,0 +1,312 @@
+package autocomplete
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+)
+
+// Shells is the list of shells for which a completion script can be generated
+var Shells = []string{"bash", "zsh", "fish", "nushell"}
+
+// completionFlag is the flag given to the CLI to get the dynamic completion
+// of the current command line
+var completionFlag = "--" + cli.BashCompletionFlag.Names()[0]
+
+// Script writes on w the completion script of the given shell. The script is
+// generated from the tree of commands of the application: each command and
+// flag is listed with its description, and the values of the arguments are
+// dynamically completed by calling the CLI with the completion flag, like the
+// bash completion does.
+func Script(w io.Writer, app *cli.App, shell string) error {
+	switch shell {
+	case "bash":
+		return bashScript(w, app)
+	case "zsh":
+		return zshScript(w, app)
+	case "fish":
+		return fishScript(w, app)
+	case "nu", "nushell":
+		return nushellScript(w, app)
+	}
+	return errgo.Newf("unsupported shell '%s', must be one of %s", shell, strings.Join(Shells, ", "))
+}
+
+// visibleCommands returns the commands of the application which should be
+// completed, sorted by name
+func visibleCommands(app *cli.App) []*cli.Command {
+	commands := []*cli.Command{}
+	for _, command := range app.Commands {
+		if command.Hidden {
+			continue
+		}
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// visibleFlags returns the flags of the command which should be completed
+func visibleFlags(flags []cli.Flag) []cli.Flag {
+	res := []cli.Flag{}
+	for _, flag := range flags {
+		if visible, ok := flag.(cli.VisibleFlag); ok && !visible.IsVisible() {
+			continue
+		}
+		res = append(res, flag)
+	}
+	return res
+}
+
+func flagUsage(flag cli.Flag) string {
+	if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+		return oneLine(docFlag.GetUsage())
+	}
+	return ""
+}
+
+func flagTakesValue(flag cli.Flag) bool {
+	if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+		return docFlag.TakesValue()
+	}
+	return false
+}
+
+// dashedFlagNames returns all the names of the flag as they are written on the
+// command line (e.g. "--app" and "-a")
+func dashedFlagNames(flag cli.Flag) []string {
+	names := []string{}
+	for _, name := range flag.Names() {
+		if len(name) == 1 {
+			names = append(names, "-"+name)
+		} else {
+			names = append(names, "--"+name)
+		}
+	}
+	return names
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func bashScript(w io.Writer, app *cli.App) error {
+	name := app.HelpName
+	_, err := fmt.Fprintf(w, `#! /bin/bash
+
+_%[1]s_bash_autocomplete() {
+     local cur opts
+     COMPREPLY=()
+     cur="${COMP_WORDS[COMP_CWORD]}"
+     opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} %[2]s 2>/dev/null )
+     COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+     return 0
+ }
+
+complete -F _%[1]s_bash_autocomplete %[1]s
+`, name, completionFlag)
+	return err
+}
+
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func zshScript(w io.Writer, app *cli.App) error {
+	name := app.HelpName
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "#compdef %s\n\n", name)
+	fmt.Fprintf(b, "_%s() {\n", name)
+	b.WriteString("  local -a commands flags values\n\n")
+	b.WriteString("  if (( CURRENT == 2 )); then\n")
+	b.WriteString("    commands=(\n")
+	for _, command := range visibleCommands(app) {
+		for _, commandName := range command.Names() {
+			fmt.Fprintf(b, "      %s\n", zshQuote(strings.ReplaceAll(commandName, ":", `\:`)+":"+oneLine(command.Usage)))
+		}
+	}
+	b.WriteString("    )\n")
+	fmt.Fprintf(b, "    _describe -t commands '%s command' commands\n", name)
+	b.WriteString("    return\n")
+	b.WriteString("  fi\n\n")
+
+	b.WriteString("  if [[ \"${words[CURRENT]}\" == -* ]]; then\n")
+	b.WriteString("    case \"${words[2]}\" in\n")
+	for _, command := range visibleCommands(app) {
+		flags := visibleFlags(command.Flags)
+		if len(flags) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "      %s)\n", strings.Join(command.Names(), "|"))
+		b.WriteString("        flags=(\n")
+		for _, flag := range flags {
+			for _, flagName := range dashedFlagNames(flag) {
+				fmt.Fprintf(b, "          %s\n", zshQuote(flagName+":"+flagUsage(flag)))
+			}
+		}
+		b.WriteString("        )\n")
+		b.WriteString("        ;;\n")
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    _describe -t flags 'flag' flags\n")
+	b.WriteString("    return\n")
+	b.WriteString("  fi\n\n")
+
+	fmt.Fprintf(b, "  values=(${(f)\"$(${words[1,CURRENT-1]} %s 2>/dev/null)\"})\n", completionFlag)
+	b.WriteString("  values=(${values:#-*})\n")
+	b.WriteString("  compadd -a values\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "compdef _%[1]s %[1]s\n", name)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+func fishScript(w io.Writer, app *cli.App) error {
+	name := app.HelpName
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "# %s completion for fish\n\n", name)
+	fmt.Fprintf(b, "function __%s_complete\n", name)
+	b.WriteString("    set -l tokens (commandline -opc)\n")
+	fmt.Fprintf(b, "    command $tokens[1] $tokens[2..-1] %s 2>/dev/null | string match -v -- '-*'\n", completionFlag)
+	b.WriteString("end\n\n")
+
+	fmt.Fprintf(b, "complete -c %s -f\n", name)
+	for _, flag := range visibleFlags(app.Flags) {
+		b.WriteString(fishFlagCompletion(name, "", flag))
+	}
+	b.WriteString("\n")
+
+	for _, command := range visibleCommands(app) {
+		for _, commandName := range command.Names() {
+			fmt.Fprintf(b, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", name, fishQuote(commandName), fishQuote(oneLine(command.Usage)))
+		}
+	}
+
+	for _, command := range visibleCommands(app) {
+		b.WriteString("\n")
+		condition := fishQuote("__fish_seen_subcommand_from " + strings.Join(command.Names(), " "))
+		for _, flag := range visibleFlags(command.Flags) {
+			b.WriteString(fishFlagCompletion(name, condition, flag))
+		}
+		if command.BashComplete != nil {
+			fmt.Fprintf(b, "complete -c %s -n %s -a '(__%s_complete)'\n", name, condition, name)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func fishFlagCompletion(name, condition string, flag cli.Flag) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "complete -c %s", name)
+	if condition != "" {
+		fmt.Fprintf(b, " -n %s", condition)
+	}
+
+	dynamic := false
+	for _, flagName := range flag.Names() {
+		if len(flagName) == 1 {
+			fmt.Fprintf(b, " -s %s", flagName)
+		} else {
+			fmt.Fprintf(b, " -l %s", flagName)
+		}
+		dynamic = dynamic || HasFlagCompleter(flagName)
+	}
+	if dynamic {
+		fmt.Fprintf(b, " -x -a '(__%s_complete)'", name)
+	} else if flagTakesValue(flag) {
+		b.WriteString(" -r")
+	}
+	if usage := flagUsage(flag); usage != "" {
+		fmt.Fprintf(b, " -d %s", fishQuote(usage))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func nushellScript(w io.Writer, app *cli.App) error {
+	name := app.HelpName
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "# %s completion for Nushell\n\n", name)
+	fmt.Fprintf(b, "def \"nu-complete %s\" [context: string] {\n", name)
+	b.WriteString("  let tokens = ($context | split row -r '\\s+' | where {|t| $t != \"\" })\n")
+	b.WriteString("  let tokens = (if ($context | str ends-with \" \") { $tokens } else { $tokens | drop 1 })\n")
+	fmt.Fprintf(b, "  ^$tokens.0 ...($tokens | skip 1) %s | lines | where {|l| not ($l | str starts-with \"-\") }\n", completionFlag)
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "export extern \"%s\" [\n", name)
+	for _, flag := range visibleFlags(app.Flags) {
+		b.WriteString(nushellFlag(name, flag))
+	}
+	fmt.Fprintf(b, "  command?: string@\"nu-complete %s\"\n", name)
+	b.WriteString("]\n")
+
+	for _, command := range visibleCommands(app) {
+		for _, commandName := range command.Names() {
+			fmt.Fprintf(b, "\n# %s\n", oneLine(command.Usage))
+			fmt.Fprintf(b, "export extern \"%s %s\" [\n", name, commandName)
+			for _, flag := range visibleFlags(command.Flags) {
+				b.WriteString(nushellFlag(name, flag))
+			}
+			if command.BashComplete != nil {
+				fmt.Fprintf(b, "  ...args: string@\"nu-complete %s\"\n", name)
+			} else {
+				b.WriteString("  ...args: string\n")
+			}
+			b.WriteString("]\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func nushellFlag(name string, flag cli.Flag) string {
+	long, short := "", ""
+	dynamic := false
+	for _, flagName := range flag.Names() {
+		if len(flagName) == 1 && short == "" {
+			short = flagName
+		} else if len(flagName) > 1 && long == "" {
+			long = flagName
+		}
+		dynamic = dynamic || HasFlagCompleter(flagName)
+	}
+	if long == "" {
+		// Nushell does not support flags with only a short name
+		return ""
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "  --%s", long)
+	if short != "" {
+		fmt.Fprintf(b, "(-%s)", short)
+	}
+	if flagTakesValue(flag) {
+		b.WriteString(": string")
+		if dynamic {
+			fmt.Fprintf(b, "@\"nu-complete %s\"", name)
+		}
+	}
+	if usage := flagUsage(flag); usage != "" {
+		fmt.Fprintf(b, " # %s", usage)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package autocomplete
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func scriptTestApp() *cli.App {
+	return &cli.App{
+		HelpName: "scalingo",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "app", Aliases: []string{"a"}, Usage: "Name of the app"},
+			&cli.StringFlag{Name: "region", Usage: "Name of the region"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:    "restart",
+				Aliases: []string{"reboot"},
+				Usage:   "Restart processes of your app",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "synchronous", Aliases: []string{"s"}, Usage: "Do the restart synchronously"},
+					&cli.StringFlag{Name: "hidden-flag", Hidden: true, Usage: "Never completed"},
+				},
+				BashComplete: func(*cli.Context) {},
+			},
+			{
+				Name:  "env-set",
+				Usage: "Set the environment variables of your app",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "remote", Aliases: []string{"r"}, Usage: "Name of the Git remote"},
+				},
+			},
+			{
+				Name:   "hidden-command",
+				Usage:  "Never completed",
+				Hidden: true,
+			},
+		},
+	}
+}
+
+func TestScript(t *testing.T) {
+	t.Run("bash calls the CLI with the completion flag", func(t *testing.T) {
+		script := &strings.Builder{}
+		err := Script(script, scriptTestApp(), "bash")
+		require.NoError(t, err)
+
+		assert.Contains(t, script.String(), "complete -F _scalingo_bash_autocomplete scalingo")
+		assert.Contains(t, script.String(), "--generate-bash-completion")
+	})
+
+	t.Run("zsh lists the commands and their flags", func(t *testing.T) {
+		script := &strings.Builder{}
+		err := Script(script, scriptTestApp(), "zsh")
+		require.NoError(t, err)
+
+		assert.Contains(t, script.String(), "#compdef scalingo")
+		assert.Contains(t, script.String(), "'restart:Restart processes of your app'")
+		assert.Contains(t, script.String(), "'reboot:Restart processes of your app'")
+		assert.Contains(t, script.String(), "restart|reboot)")
+		assert.Contains(t, script.String(), "'--synchronous:Do the restart synchronously'")
+		assert.Contains(t, script.String(), "'-s:Do the restart synchronously'")
+		assert.Contains(t, script.String(), "'--remote:Name of the Git remote'")
+		assert.NotContains(t, script.String(), "hidden-command")
+		assert.NotContains(t, script.String(), "hidden-flag")
+	})
+
+	t.Run("fish lists the commands and completes the dynamic values", func(t *testing.T) {
+		script := &strings.Builder{}
+		err := Script(script, scriptTestApp(), "fish")
+		require.NoError(t, err)
+
+		assert.Contains(t, script.String(), "complete -c scalingo -l app -s a -x -a '(__scalingo_complete)' -d 'Name of the app'")
+		assert.Contains(t, script.String(), "complete -c scalingo -l region -r -d 'Name of the region'")
+		assert.Contains(t, script.String(), "complete -c scalingo -n __fish_use_subcommand -a 'restart' -d 'Restart processes of your app'")
+		assert.Contains(t, script.String(), "complete -c scalingo -n __fish_use_subcommand -a 'reboot' -d 'Restart processes of your app'")
+		assert.Contains(t, script.String(), "complete -c scalingo -n '__fish_seen_subcommand_from restart reboot' -l synchronous -s s -d 'Do the restart synchronously'")
+		assert.Contains(t, script.String(), "complete -c scalingo -n '__fish_seen_subcommand_from restart reboot' -a '(__scalingo_complete)'")
+		assert.Contains(t, script.String(), "complete -c scalingo -n '__fish_seen_subcommand_from env-set' -l remote -s r -x -a '(__scalingo_complete)' -d 'Name of the Git remote'")
+		assert.NotContains(t, script.String(), "hidden-command")
+		assert.NotContains(t, script.String(), "hidden-flag")
+	})
+
+	t.Run("nushell declares an extern per command", func(t *testing.T) {
+		script := &strings.Builder{}
+		err := Script(script, scriptTestApp(), "nushell")
+		require.NoError(t, err)
+
+		assert.Contains(t, script.String(), "  --app(-a): string@\"nu-complete scalingo\" # Name of the app\n")
+		assert.Contains(t, script.String(), "  --region: string # Name of the region\n")
+		assert.Contains(t, script.String(), "export extern \"scalingo restart\" [\n  --synchronous(-s) # Do the restart synchronously\n  ...args: string@\"nu-complete scalingo\"\n]")
+		assert.Contains(t, script.String(), "export extern \"scalingo reboot\" [")
+		assert.Contains(t, script.String(), "export extern \"scalingo env-set\" [\n  --remote(-r): string@\"nu-complete scalingo\" # Name of the Git remote\n  ...args: string\n]")
+		assert.NotContains(t, script.String(), "hidden-command")
+		assert.NotContains(t, script.String(), "hidden-flag")
+	})
+
+	t.Run("nu is an alias of nushell", func(t *testing.T) {
+		nu := &strings.Builder{}
+		err := Script(nu, scriptTestApp(), "nu")
+		require.NoError(t, err)
+
+		nushell := &strings.Builder{}
+		err = Script(nushell, scriptTestApp(), "nushell")
+		require.NoError(t, err)
+
+		assert.Equal(t, nushell.String(), nu.String())
+	})
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		err := Script(&strings.Builder{}, scriptTestApp(), "powershell")
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "unsupported shell 'powershell'")
+	})
+}
@@ -0,0 +1,22 @@
+package autocomplete
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/tasks"
+)
+
+func TaskNamesAutoComplete(c *cli.Context) error {
+	definedTasks, err := tasks.Load()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	for _, task := range definedTasks {
+		fmt.Println(task.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/billing"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+var (
+	invoicesCommand = cli.Command{
+		Name:     "invoices",
+		Category: "Billing",
+		Usage:    "List the invoices of your account",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "page", Usage: "Page to display", Value: 1},
+			&cli.IntFlag{Name: "per-page", Usage: "Number of invoices to display", Value: 20},
+		},
+		Description: CommandDescription{
+			Description: "List the invoices of your account with their totals, the most recent first",
+			Examples: []string{
+				"scalingo invoices",
+				"scalingo invoices --page 2 --per-page 12",
+			},
+			SeeAlso: []string{"invoice-show", "invoice-download"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "invoices")
+				return nil
+			}
+
+			err := billing.Invoices(c.Context, scalingo.PaginationOpts{
+				Page:    c.Int("page"),
+				PerPage: c.Int("per-page"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoices")
+		},
+	}
+
+	invoiceShowCommand = cli.Command{
+		Name:      "invoice-show",
+		Category:  "Billing",
+		Usage:     "Display an invoice of your account with its items",
+		ArgsUsage: "invoice-id",
+		Description: CommandDescription{
+			Description: "Display an invoice of your account with its items, detailed per app",
+			Examples:    []string{"scalingo invoice-show 5d3b2f2b-1d2b-4a4e-8a4e-1a2b3c4d5e6f"},
+			SeeAlso:     []string{"invoices", "invoice-download"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "invoice-show")
+				return nil
+			}
+
+			err := billing.InvoiceShow(c.Context, c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoice-show")
+		},
+	}
+
+	invoiceDownloadCommand = cli.Command{
+		Name:      "invoice-download",
+		Category:  "Billing",
+		Usage:     "Download the PDF of an invoice of your account",
+		ArgsUsage: "invoice-id",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "Path of the PDF, or directory where it is written (default: <invoice-number>.pdf)"},
+		},
+		Description: CommandDescription{
+			Description: "Download the PDF of an invoice of your account",
+			Examples: []string{
+				"scalingo invoice-download 5d3b2f2b-1d2b-4a4e-8a4e-1a2b3c4d5e6f",
+				"scalingo invoice-download 5d3b2f2b-1d2b-4a4e-8a4e-1a2b3c4d5e6f -o invoice.pdf",
+			},
+			SeeAlso: []string{"invoices", "invoice-show"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "invoice-download")
+				return nil
+			}
+
+			err := billing.InvoiceDownload(c.Context, c.Args().First(), c.String("output"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoice-download")
+		},
+	}
+
+	billingProfileCommand = cli.Command{
+		Name:     "billing-profile",
+		Category: "Billing",
+		Usage:    "Display the billing profile of your account",
+		Description: CommandDescription{
+			Description: "Display the billing profile of your account: company, VAT number and payment method",
+			Examples:    []string{"scalingo billing-profile"},
+			SeeAlso:     []string{"invoices"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "billing-profile")
+				return nil
+			}
+
+			err := billing.Profile(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "billing-profile")
+		},
+	}
+)
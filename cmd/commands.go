@@ -65,19 +65,33 @@ func (cmds *AppCommands) addCommand(cmd Command) {
 	// This argument disables the global help command, but doesn't disable the flag.
 	cmd.Command.HideHelpCommand = true
 
+	if auditedCommands[cmd.Command.Name] {
+		cmd.Command.Action = auditedCommandAction(cmd.Command.Action)
+	}
+
+	// Each app selected by the fleet flags is handled by its own audited
+	// execution of the command
+	if fleetCommands[cmd.Command.Name] {
+		cmd.Command.Flags = append(cmd.Command.Flags, fleetFlags...)
+		cmd.Command.Action = fleetCommandAction(cmd.Command.Action)
+	}
+
 	// Global commands are simply added to the list of commands
 	if cmd.Global {
 		return
 	}
 
 	// Regional commands are modified before being added to the list of commands.
-	regionFlag := &cli.StringFlag{Name: "region", Value: "", Usage: "Name of the region to use"}
-	cmd.Command.Flags = append(cmd.Command.Flags, regionFlag)
+	ownRegionFlag := ownRegionFlagCommands[cmd.Command.Name]
+	if !ownRegionFlag {
+		regionFlag := &cli.StringFlag{Name: "region", Value: "", Usage: "Name of the region to use"}
+		cmd.Command.Flags = append(cmd.Command.Flags, regionFlag)
+	}
 	action := cmd.Command.Action
-	cmd.Command.Action = regionalCommandAction(action)
+	cmd.Command.Action = regionalCommandAction(action, ownRegionFlag)
 }
 
-func regionalCommandAction(action cli.ActionFunc) cli.ActionFunc {
+func regionalCommandAction(action cli.ActionFunc, ownRegionFlag bool) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		token := os.Getenv("SCALINGO_API_TOKEN")
 
@@ -95,7 +109,13 @@ func regionalCommandAction(action cli.ActionFunc) cli.ActionFunc {
 		if err != nil {
 			errorQuit(err)
 		}
-		currentRegion := regionNameFromFlags(c)
+		regionContext := c
+		if ownRegionFlag && len(c.Lineage()) > 1 {
+			// The region flag of the command is not the region to use, which
+			// can only be given before the command name
+			regionContext = c.Lineage()[1]
+		}
+		currentRegion := regionNameFromFlags(regionContext)
 
 		// Detecting Region from git remote
 		if currentRegion == "" {
@@ -134,7 +154,7 @@ func (cmds *AppCommands) Commands() []*cli.Command {
 func NewAppCommands() *AppCommands {
 	cmds := AppCommands{}
 	for _, cmd := range regionalCommands {
-		cmds.addCommand(Command{Command: cmd})
+		cmds.addCommand(Command{Global: regionlessCommands[cmd.Name], Command: cmd})
 	}
 	for _, cmd := range globalCommands {
 		cmds.addCommand(Command{Global: true, Command: cmd})
@@ -142,6 +162,19 @@ func NewAppCommands() *AppCommands {
 	return &cmds
 }
 
+// regionlessCommands are listed with the regional commands they relate to, but
+// they do not use the Scalingo API of a region and are added as global commands
+var regionlessCommands = map[string]bool{
+	"run-replay": true, "scheduler": true,
+}
+
+// ownRegionFlagCommands define their own --region flag, with another meaning
+// than the region to use: the region of the application created by
+// apps-clone
+var ownRegionFlagCommands = map[string]bool{
+	"apps-clone": true,
+}
+
 var (
 	regionalCommands = []*cli.Command{
 		// Apps
@@ -150,6 +183,8 @@ var (
 		&DestroyCommand,
 		&renameCommand,
 		&appsInfoCommand,
+		&appsCloneCommand,
+		&costsCommand,
 		&openCommand,
 		&dashboardCommand,
 
@@ -157,13 +192,26 @@ var (
 		&logsCommand,
 		&logsArchivesCommand,
 		&runCommand,
+		&runReplayCommand,
+		&taskCommand,
+		&oneOffsCommand,
+		&oneOffLogsCommand,
+		&oneOffWaitCommand,
 		&oneOffStopCommand,
 
 		// Apps Process Actions
 		&psCommand,
 		&scaleCommand,
+		&containerSizesCommand,
+		&scalePresetCommand,
+		&scalePresetsCommand,
+		&scaleScheduleCommand,
+		&scaleSchedulesCommand,
+		&schedulerCommand,
 		&RestartCommand,
 		&sendSignalCommand,
+		&operationsCommand,
+		&operationWaitCommand,
 
 		// Routing Settings
 		&forceHTTPSCommand,
@@ -175,6 +223,7 @@ var (
 		// Events
 		&UserTimelineCommand,
 		&TimelineCommand,
+		&eventsCommand,
 
 		// Environment
 		&envCommand,
@@ -187,6 +236,9 @@ var (
 		&DomainsAddCommand,
 		&DomainsRemoveCommand,
 		&DomainsSSLCommand,
+		&DomainsCheckCommand,
+		&DomainsCertsCommand,
+		&DomainsImportCommand,
 
 		// Deployments
 		&deploymentsListCommand,
@@ -223,6 +275,10 @@ var (
 
 		// Review Apps
 		&reviewAppsShowCommand,
+		&reviewAppsDestroyCommand,
+		&reviewAppsOpenCommand,
+		&reviewAppCreateCommand,
+		&reviewAppDestroyCommand,
 
 		// Notifiers
 		&NotifiersListCommand,
@@ -273,6 +329,7 @@ var (
 		&autoscalersEnableCommand,
 
 		// Migrations
+		&migrationCheckCommand,
 		&migrationCreateCommand,
 		&migrationRunCommand,
 		&migrationAbortCommand,
@@ -309,12 +366,24 @@ var (
 		&ConfigCommand,
 		&selfCommand,
 
+		// Billing
+		&invoicesCommand,
+		&invoiceShowCommand,
+		&invoiceDownloadCommand,
+		&billingProfileCommand,
+
+		// Audit
+		&auditLogCommand,
+
 		// Version
 		&UpdateCommand,
 
 		// Changelog
 		&changelogCommand,
 
+		// Completion
+		&completionCommand,
+
 		// Help
 		&HelpCommand,
 	}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+)
+
+var (
+	completionCommand = cli.Command{
+		Name:      "completion",
+		Category:  "CLI Internals",
+		Usage:     "Generate the completion script of your shell",
+		ArgsUsage: "<" + strings.Join(autocomplete.Shells, "|") + ">",
+		Description: CommandDescription{
+			Description: "Generate the completion script of the given shell. The script is generated from the list of commands of the CLI and completes commands, flags and the dynamic values like applications, addons or remotes.",
+			Examples: []string{
+				"scalingo completion bash > /etc/bash_completion.d/scalingo",
+				"scalingo completion zsh > \"${fpath[1]}/_scalingo\"",
+				"scalingo completion fish > ~/.config/fish/completions/scalingo.fish",
+				"scalingo completion nushell | save -f ~/.config/nushell/scalingo-completions.nu",
+			},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				return cli.ShowCommandHelp(c, "completion")
+			}
+
+			err := autocomplete.Script(os.Stdout, c.App, c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			for _, shell := range autocomplete.Shells {
+				c.App.Writer.Write([]byte(shell + "\n"))
+			}
+		},
+	}
+)
@@ -14,10 +14,16 @@ var (
 		Usage:    "Configure the CLI",
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "region", Value: "", Usage: "Configure the default region used by the CLI"},
+			&cli.StringFlag{Name: "audit-hook", Usage: "Command receiving each record of the audit trail on its standard input, empty to disable it"},
+			&cli.BoolFlag{Name: "record-runs", Usage: "Record the sessions of the one-off containers by default (--record-runs=false to disable it)"},
 		},
 		Description: CommandDescription{
-			Description: "Configure the CLI.\n\nCan also be configured using the environment variable SCALINGO_REGION",
-			Examples:    []string{"scalingo config --region agora-fr1"},
+			Description: "Configure the CLI.\n\nCan also be configured using the environment variables SCALINGO_REGION, SCALINGO_AUDIT_HOOK and SCALINGO_RECORD_RUNS",
+			Examples: []string{
+				"scalingo config --region agora-fr1",
+				"scalingo config --audit-hook 'logger -t scalingo-audit'",
+				"scalingo config --record-runs",
+			},
 		}.Render(),
 		Action: func(c *cli.Context) error {
 			regionName := regionNameFromFlags(c)
@@ -28,8 +34,22 @@ var (
 				}
 			}
 
+			if c.IsSet("audit-hook") {
+				err := config.SetAuditHook(c.String("audit-hook"))
+				if err != nil {
+					errorQuit(err)
+				}
+			}
+
+			if c.IsSet("record-runs") {
+				err := config.SetRecordRuns(c.Bool("record-runs"))
+				if err != nil {
+					errorQuit(err)
+				}
+			}
+
 			// If no flag are given, display the current config
-			if regionName == "" {
+			if regionName == "" && !c.IsSet("audit-hook") && !c.IsSet("record-runs") {
 				config.Display()
 			}
 			return nil
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/containersizes"
+)
+
+var (
+	containerSizesCommand = cli.Command{
+		Name:     "container-sizes",
+		Category: "App Management",
+		Usage:    "List the container sizes with their memory, CPU and price",
+		Description: CommandDescription{
+			Description: `List the container sizes with their memory, swap, CPU share, price per hour and per 30 days, and whether they are available in the current region.
+The sizes are the values accepted by the --size flags and by the 'type:amount:size' arguments of 'scale'.`,
+			Examples: []string{
+				"scalingo container-sizes",
+				"scalingo --region osc-secnum-fr1 container-sizes",
+			},
+			SeeAlso: []string{"scale", "run", "costs"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "container-sizes")
+				return nil
+			}
+
+			err := containersizes.List(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "container-sizes")
+		},
+	}
+)
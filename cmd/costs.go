@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/costs"
+	"github.com/Scalingo/cli/detect"
+)
+
+var (
+	costsCommand = cli.Command{
+		Name:     "costs",
+		Category: "App Management",
+		Usage:    "Estimate the monthly cost of your applications",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "all", Usage: "Estimate the monthly cost of all the applications of the region"},
+		},
+		Description: CommandDescription{
+			Description: `Estimate the monthly cost of the current formation and addons plans of an application, or of all the applications of the region with --all.
+The estimation is based on the public prices of the container sizes and of the addons plans, for 30 days and excluding taxes.`,
+			Examples: []string{
+				"scalingo --app my-app costs",
+				"scalingo costs --all",
+			},
+			SeeAlso: []string{"scale", "addons-upgrade"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "costs")
+				return nil
+			}
+
+			var err error
+			if c.Bool("all") {
+				err = costs.All(c.Context)
+			} else {
+				err = costs.App(c.Context, detect.CurrentApp(c))
+			}
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "costs")
+		},
+	}
+)
@@ -156,6 +156,155 @@ var (
 		},
 	}
 
+	DomainsCheckCommand = cli.Command{
+		Name:      "domains-check",
+		Category:  "Custom Domains",
+		Usage:     "Diagnose the DNS configuration of your custom domains",
+		ArgsUsage: "[domain]",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "resolver", Usage: "Address (host:port) of the DNS server to query instead of the system resolver"},
+		},
+		Description: CommandDescription{
+			Description: `Resolve the DNS records of the custom domains of an application and compare them to the hostname of the application.
+When the Let's Encrypt certificate of a domain requires a DNS challenge, the challenge record is verified too.
+
+For each misconfigured domain, the records to create are explained. Without argument, all the domains of the application are checked.`,
+			Examples: []string{
+				"scalingo --app my-app domains-check",
+				"scalingo --app my-app domains-check www.example.com",
+				"scalingo --app my-app domains-check --resolver 1.1.1.1:53 www.example.com",
+			},
+			SeeAlso: []string{"domains", "domains-add"},
+		}.Render(),
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() > 1 {
+				return cli.ShowCommandHelp(c, "domains-check")
+			}
+
+			currentApp := detect.CurrentApp(c)
+			err := domains.Check(c.Context, currentApp, c.Args().First(), c.String("resolver"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "domains-check")
+			autocomplete.DomainsRemoveAutoComplete(c)
+		},
+	}
+
+	DomainsCertsCommand = cli.Command{
+		Name:     "domains-certs",
+		Category: "Custom Domains",
+		Usage:    "Report the expiration of the certificates manually installed on your custom domains",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "all-apps", Usage: "Report the certificates of all your applications"},
+			&cli.StringFlag{Name: "expiring-within", Usage: "Fail if a certificate expires within this period (e.g. 30d, 72h)", Value: "30d"},
+		},
+		Description: CommandDescription{
+			Description: `List the certificates manually installed on custom domains with their issuer, the names they cover and their expiration date.
+Certificates managed by Let's Encrypt are renewed automatically and are not listed.
+
+The command exits with a non-zero status if a certificate is expired or expires within the period given with --expiring-within (30 days by default), it can be used in a periodic job to be warned before a certificate expires.`,
+			Examples: []string{
+				"scalingo --app my-app domains-certs",
+				"scalingo domains-certs --all-apps --expiring-within 30d",
+			},
+			SeeAlso: []string{"domains", "domains-ssl"},
+		}.Render(),
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				return cli.ShowCommandHelp(c, "domains-certs")
+			}
+
+			expiringWithin, err := utils.ParseDuration(c.String("expiring-within"))
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "domains-certs")
+			}
+
+			opts := domains.CertsReportOpts{
+				AllApps:        c.Bool("all-apps"),
+				ExpiringWithin: expiringWithin,
+			}
+			if !opts.AllApps {
+				opts.App = detect.CurrentApp(c)
+			}
+
+			err = domains.CertsReport(c.Context, opts)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "domains-certs")
+		},
+	}
+
+	DomainsImportCommand = cli.Command{
+		Name:     "domains-import",
+		Category: "Custom Domains",
+		Usage:    "Synchronize the custom domains of an application with a CSV or YAML file",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "CSV or YAML file listing the domains", Required: true},
+			&cli.StringFlag{Name: "format", Usage: "Format of the file (csv or yaml), guessed from the file extension by default"},
+			&cli.BoolFlag{Name: "prune", Usage: "Remove the domains which are not listed in the file"},
+			&cli.BoolFlag{Name: "yes", Usage: "Remove the domains with --prune without asking for a confirmation /!\\"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only display the changes which would be applied"},
+		},
+		Description: CommandDescription{
+			Description: `Add the domains listed in a file which are missing from the application, install their certificates and set the canonical domain.
+With --prune, the domains of the application which are not listed in the file are removed once the removal is confirmed, or without confirmation with --yes. A file listing no domain is refused with --prune.
+
+The CSV file has the columns domain,cert,key,canonical, the header line is optional:
+
+  domain,cert,key,canonical
+  www.example.com,certs/www.crt,certs/www.key,true
+  example.com,,,
+
+The YAML file has the following structure:
+
+  domains:
+  - name: www.example.com
+    cert: certs/www.crt
+    key: certs/www.key
+    canonical: true
+  - name: example.com
+
+Paths of the certificates and keys are relative to the directory of the file. They are validated before any change is applied.`,
+			Examples: []string{
+				"scalingo --app my-app domains-import -f domains.csv --dry-run",
+				"scalingo --app my-app domains-import -f domains.yml --prune",
+			},
+			SeeAlso: []string{"domains", "domains-add", "domains-remove", "set-canonical-domain"},
+		}.Render(),
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				return cli.ShowCommandHelp(c, "domains-import")
+			}
+
+			currentApp := detect.CurrentApp(c)
+			err := domains.Import(c.Context, currentApp, domains.ImportOpts{
+				File:   c.String("file"),
+				Format: c.String("format"),
+				Prune:  c.Bool("prune"),
+				DryRun: c.Bool("dry-run"),
+				Yes:    c.Bool("yes"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "domains-import")
+		},
+	}
+
 	setCanonicalDomainCommand = cli.Command{
 		Name:      "set-canonical-domain",
 		Category:  "App Management",
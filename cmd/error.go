@@ -58,18 +58,35 @@ func (r *ReportError) Report() {
 	//rollbar.ErrorWithStack(rollbar.ERR, r.Error, errgorollbar.BuildStack(r.Error), fields...)
 }
 
+// osExit exits the process, it is replaced in the tests
+var osExit = os.Exit
+
 func errorQuitWithHelpMessage(err error, ctxCli *cli.Context, command string) {
+	completeAuditRecord(err)
 	displayError(err)
 	fmt.Print("\n")
 	_ = cli.ShowCommandHelp(ctxCli, command)
 
-	os.Exit(1)
+	osExit(1)
 }
 
 func errorQuit(err error) {
+	completeAuditRecord(err)
 	displayError(err)
 
-	os.Exit(1)
+	osExit(1)
+}
+
+// exitWithCode exits with the exit code of a one-off once the audit record of
+// the command is written, a non-zero exit code is recorded as a failure
+func exitWithCode(code int) {
+	var err error
+	if code != 0 {
+		err = errgo.Newf("the one-off exited with code %d", code)
+	}
+	completeAuditRecord(err)
+
+	osExit(code)
 }
 
 func displayError(err error) {
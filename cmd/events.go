@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/events"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+var (
+	eventsCommand = cli.Command{
+		Name:     "events",
+		Category: "Events",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "app", Aliases: []string{"a"}, Usage: "Comma separated list of apps to watch, all the events of your account by default"},
+			&cli.BoolFlag{Name: "follow", Aliases: []string{"f"}, Usage: "Keep displaying the new events as they arrive"},
+			&cli.DurationFlag{Name: "interval", Usage: "Time between two polls of the events", Value: events.DefaultFollowInterval},
+			&cli.IntFlag{Name: "lines", Aliases: []string{"n"}, Usage: "Number of past events to display", Value: 10},
+			&cli.StringSliceFlag{Name: "hook", Usage: "Command executed for each new event of a type, as type=command ('*' for all the types)"},
+		},
+		Usage: "Display the latest events of your apps, and follow them live",
+		Description: CommandDescription{
+			Description: `Display the latest events of your account or of a list of apps. With --follow, the new events are displayed as they arrive.
+
+A local command can be executed for each new event of a given type with --hook. The event is given as JSON on its standard input and the environment variables SCALINGO_EVENT_ID, SCALINGO_EVENT_TYPE and SCALINGO_EVENT_APP are defined.`,
+			Examples: []string{
+				"scalingo events --follow",
+				"scalingo events --follow --app my-app,my-other-app",
+				"scalingo events --follow --hook 'crash=notify-send \"$SCALINGO_EVENT_APP crashed\"' --hook 'deployment=./on-deploy.sh'",
+			},
+			SeeAlso: []string{"timeline", "user-timeline"},
+		}.Render(),
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				return cli.ShowCommandHelp(c, "events")
+			}
+
+			hooks, err := parseEventHooks(c.StringSlice("hook"))
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "events")
+			}
+			if len(hooks) > 0 && !c.Bool("follow") {
+				errorQuitWithHelpMessage(errgo.New("--hook can only be used with --follow"), c, "events")
+			}
+			if c.Int("lines") < 0 {
+				errorQuitWithHelpMessage(errgo.New("--lines must be positive or zero"), c, "events")
+			}
+
+			client, err := config.ScalingoClient(c.Context)
+			if err != nil {
+				errorQuit(errgo.Notef(err, "fail to get Scalingo client"))
+			}
+
+			opts := events.FollowOpts{
+				Interval: c.Duration("interval"),
+				Lines:    c.Int("lines"),
+				Hooks:    hooks,
+			}
+			appNames := splitFlagValues([]string{eventsAppFlag(c)})
+			// The app name is useless if the events of a single app are displayed
+			opts.DisplayAppName = len(appNames) != 1
+			if len(appNames) == 0 {
+				opts.Sources = append(opts.Sources, client.UserEventsList)
+			}
+			for _, app := range appNames {
+				app := app
+				opts.Sources = append(opts.Sources, func(ctx context.Context, paginationOpts scalingo.PaginationOpts) (scalingo.Events, scalingo.PaginationMeta, error) {
+					return client.EventsList(ctx, app, paginationOpts)
+				})
+			}
+
+			if c.Bool("follow") {
+				err = events.Follow(c.Context, opts)
+			} else {
+				err = events.Latest(c.Context, opts)
+			}
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "events")
+		},
+	}
+)
+
+// eventsAppFlag returns the value of the app flag given either to the command
+// or globally, with the same precedence as detect.CurrentApp, but without
+// falling back on the Git remote: no app means all the apps.
+func eventsAppFlag(c *cli.Context) string {
+	for _, cliContext := range c.Lineage() {
+		app := cliContext.String("app")
+		if app != "" && app != "<name>" {
+			return app
+		}
+	}
+	return ""
+}
+
+// parseEventHooks parses the hooks given as type=command
+func parseEventHooks(values []string) (map[scalingo.EventTypeName][]string, error) {
+	hooks := map[scalingo.EventTypeName][]string{}
+	for _, value := range values {
+		eventType, command, ok := strings.Cut(value, "=")
+		eventType = strings.TrimSpace(eventType)
+		if !ok || eventType == "" || strings.TrimSpace(command) == "" {
+			return nil, errgo.Newf("invalid hook '%s', format is type=command", value)
+		}
+		hooks[scalingo.EventTypeName(eventType)] = append(hooks[scalingo.EventTypeName(eventType)], command)
+	}
+	return hooks, nil
+}
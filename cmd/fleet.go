@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/fleet"
+)
+
+// fleetCommands are the commands which can be run against several apps at
+// once with the --apps, --apps-from and --apps-match flags
+var fleetCommands = map[string]bool{
+	"restart": true, "scale": true, "send-signal": true,
+	"force-https": true, "sticky-session": true, "router-logs": true,
+	"env-set": true, "env-unset": true, "deployment-delete-cache": true,
+	"collaborators-add": true, "collaborators-remove": true, "stacks-set": true,
+	"addons-add": true, "addons-upgrade": true, "notifiers-add": true,
+	"log-drains-add": true, "log-drains-remove": true,
+}
+
+var fleetFlags = []cli.Flag{
+	&cli.StringFlag{Name: "apps", Usage: "Run the command on these apps, separated by commas"},
+	&cli.StringFlag{Name: "apps-from", Usage: "Run the command on the apps listed in this file, one per line ('-' for the standard input)"},
+	&cli.StringFlag{Name: "apps-match", Usage: "Run the command on the apps of the region matching this pattern (e.g. 'prefix-*')"},
+	&cli.IntFlag{Name: "apps-concurrency", Usage: "Amount of apps handled in parallel with --apps, --apps-from or --apps-match", Value: fleet.DefaultConcurrency},
+}
+
+// fleetCommandAction runs the action once per selected app when one of the
+// fleet flags is used, and as is otherwise
+func fleetCommandAction(action cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		selector := fleet.Selector{
+			Apps:      c.String("apps"),
+			AppsFrom:  c.String("apps-from"),
+			AppsMatch: c.String("apps-match"),
+		}
+		if !selector.IsSet() {
+			return action(c)
+		}
+		if c.IsSet("app") || os.Getenv("SCALINGO_APP") != "" {
+			errorQuitWithHelpMessage(errgo.New("--app and SCALINGO_APP cannot be used with --apps, --apps-from or --apps-match"), c, c.Command.Name)
+		}
+
+		apps, err := selector.Resolve(c.Context)
+		if err != nil {
+			errorQuit(err)
+		}
+
+		err = fleet.Run(c.Context, fleet.RunOpts{
+			Apps:        apps,
+			Args:        fleet.RemoveFlags(os.Args, "apps", "apps-from", "apps-match", "apps-concurrency"),
+			Region:      config.C.ScalingoRegion,
+			Concurrency: c.Int("apps-concurrency"),
+		})
+		if err != nil {
+			errorQuit(err)
+		}
+		return nil
+	}
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"regexp"
-
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
@@ -31,22 +29,13 @@ var (
 				cli.ShowCommandHelp(c, "one-off-stop")
 				return nil
 			}
-			oneOffLabel := c.Args().First()
-
-			// If oneOffLabel only contains digits, the client typed something like:
+			// The client may only type the number of the one-off:
 			//   scalingo one-off-stop 1234
-			labelHasOnlyDigit, err := regexp.MatchString("^[0-9]+$", oneOffLabel)
-			if err != nil {
-				// This should never occur as we are pretty sure the provided regexp is valid.
-				errorQuit(err)
-			}
-			if labelHasOnlyDigit {
-				oneOffLabel = "one-off-" + oneOffLabel
-			}
+			oneOffLabel := apps.OneOffLabel(c.Args().First())
 
 			utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeContainers)
 
-			err = apps.OneOffStop(c.Context, currentApp, oneOffLabel)
+			err := apps.OneOffStop(c.Context, currentApp, oneOffLabel)
 			if err != nil {
 				errorQuit(err)
 			}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/io"
+)
+
+var (
+	oneOffsCommand = cli.Command{
+		Name:     "one-offs",
+		Category: "App Management",
+		Usage:    "List the running one-off containers of an application",
+		Flags:    []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: "List the running one-off containers of an application with their command, size and age",
+			Examples:    []string{"scalingo --app my-app one-offs"},
+			SeeAlso:     []string{"one-off-logs", "one-off-wait", "one-off-stop"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "one-offs")
+				return nil
+			}
+
+			err := apps.OneOffs(c.Context, currentApp)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "one-offs")
+		},
+	}
+
+	oneOffLogsCommand = cli.Command{
+		Name:      "one-off-logs",
+		Category:  "App Management",
+		Usage:     "Stream the logs of a one-off container",
+		ArgsUsage: "container-id",
+		Flags: []cli.Flag{&appFlag,
+			&cli.IntFlag{Name: "lines", Aliases: []string{"n"}, Value: 20, Usage: "Number of log lines to dump before streaming"},
+		},
+		Description: CommandDescription{
+			Description: "Stream the logs of a one-off container, typically started with 'run --detached'",
+			Examples: []string{
+				"scalingo --app my-app one-off-logs one-off-1234",
+				"scalingo --app my-app one-off-logs 1234",
+			},
+			SeeAlso: []string{"one-offs", "one-off-wait", "logs"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "one-off-logs")
+				return nil
+			}
+
+			err := apps.OneOffLogs(c.Context, currentApp, apps.OneOffLabel(c.Args().First()), c.Int("lines"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "one-off-logs")
+		},
+	}
+
+	oneOffWaitCommand = cli.Command{
+		Name:      "one-off-wait",
+		Category:  "App Management",
+		Usage:     "Wait for a one-off container to exit",
+		ArgsUsage: "container-id",
+		Flags:     []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: `Wait for a one-off container to exit and report its exit code
+
+The command exits with the exit code of the one-off. The exit code is only
+known for the one-offs started with 'run --detached' from this computer.`,
+			Examples: []string{
+				"scalingo --app my-app one-off-wait one-off-1234",
+				"scalingo --app my-app run --detached rake db:migrate && scalingo --app my-app one-off-wait 1234",
+			},
+			SeeAlso: []string{"one-offs", "one-off-logs", "run"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "one-off-wait")
+				return nil
+			}
+			oneOffLabel := apps.OneOffLabel(c.Args().First())
+
+			exitCode, err := apps.OneOffWait(c.Context, currentApp, oneOffLabel)
+			if err != nil {
+				errorQuit(err)
+			}
+			if exitCode < 0 {
+				io.Statusf("The one-off %s exited, its exit code is unknown\n", oneOffLabel)
+				return nil
+			}
+			if exitCode != 0 {
+				io.Errorf("The one-off %s exited with code %d\n", oneOffLabel, exitCode)
+				os.Exit(exitCode)
+			}
+			io.Statusf("The one-off %s exited with code %d\n", oneOffLabel, exitCode)
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "one-off-wait")
+		},
+	}
+)
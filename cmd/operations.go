@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+)
+
+var (
+	operationsCommand = cli.Command{
+		Name:     "operations",
+		Category: "App Management",
+		Usage:    "List the operations started on your app",
+		Flags:    []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: `List the operations (scale, restart) of your application started from this computer, with their current status.
+
+The ID of an operation can be given to 'operation-wait' to wait for its end.`,
+			Examples: []string{"scalingo --app my-app operations"},
+			SeeAlso:  []string{"operation-wait", "scale", "restart"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				return cli.ShowCommandHelp(c, "operations")
+			}
+
+			currentApp := detect.CurrentApp(c)
+			err := apps.OperationsList(c.Context, currentApp)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "operations")
+		},
+	}
+
+	operationWaitCommand = cli.Command{
+		Name:      "operation-wait",
+		Category:  "App Management",
+		Usage:     "Wait for the end of operations of your app",
+		ArgsUsage: "operation-id...",
+		Flags:     []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: "Wait for the end of one or several operations of your application. The command fails if one of the operations failed.",
+			Examples: []string{
+				"scalingo --app my-app operation-wait 3b7e3b5e-8c2a-4f8e-9a5f-6d3c2b1a0f9e",
+				"scalingo --app my-app operation-wait $(scalingo --app my-app scale --async web:2) $(scalingo --app my-app restart --async worker)",
+			},
+			SeeAlso: []string{"operations", "scale", "restart"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() == 0 {
+				return cli.ShowCommandHelp(c, "operation-wait")
+			}
+
+			currentApp := detect.CurrentApp(c)
+			err := apps.OperationsWait(c.Context, currentApp, c.Args().Slice())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "operation-wait")
+		},
+	}
+)
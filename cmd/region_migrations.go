@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
@@ -11,6 +13,39 @@ import (
 )
 
 var (
+	migrationCheckCommand = cli.Command{
+		Name:     "migration-check",
+		Category: "Region migrations",
+		Flags: []cli.Flag{
+			&appFlag,
+			&cli.StringFlag{Name: "to", Usage: "Select the destination region", Required: true},
+		},
+		Usage: "Check what needs attention before migrating an app to another region",
+		Description: CommandDescription{
+			Description: `Inspect the app and the destination region before creating a migration.
+The addons, domains, SSL certificates, log drains, SCM link, cron tasks and environment variables referencing hostnames of the current region are listed with what will need a manual action.
+Nothing is created. The command fails if a problem prevents the migration, like an addon plan unavailable in the destination region.`,
+			Examples: []string{"scalingo --app my-app migration-check --to osc-fr1"},
+			SeeAlso:  []string{"migration-create"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "migration-check")
+				return nil
+			}
+
+			err := regionmigrations.Check(c.Context, currentApp, c.String("to"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			_ = autocomplete.CmdFlagsAutoComplete(c, "migration-check")
+		},
+	}
+
 	migrationCreateCommand = cli.Command{
 		Name:     "migration-create",
 		Category: "Region migrations",
@@ -53,14 +88,36 @@ var (
 			&cli.BoolFlag{Name: "prepare", Usage: "Create an empty canvas on the new region"},
 			&cli.BoolFlag{Name: "data", Usage: "Import databases (and their data) to the new region"},
 			&cli.BoolFlag{Name: "finalize", Usage: "Stop the old app and start the new one"},
+			&cli.BoolFlag{Name: "all-steps", Usage: "Run all the remaining steps of the migration, from its current status"},
+			&cli.BoolFlag{Name: "yes", Usage: "Do not ask for a confirmation before each step"},
+			&cli.StringFlag{Name: "to", Usage: "Destination region of the migration to create if the app has no ongoing migration (with --all-steps)"},
+			&cli.StringFlag{Name: "apps-from", Usage: "File listing the apps to migrate, one per line (batch mode, requires --all-steps and --yes)"},
+			&cli.IntFlag{Name: "concurrency", Usage: "Amount of migrations run in parallel in batch mode", Value: regionmigrations.DefaultBatchConcurrency},
+			&cli.StringFlag{Name: "report", Usage: "Path of the JSON report of the migrations written in batch mode"},
 		},
-		Usage: "Run a specific migration step",
+		Usage:     "Run a specific migration step",
+		ArgsUsage: "[migration-id]",
 		Description: CommandDescription{
-			Description: "Run a migration step",
-			Examples:    []string{"scalingo --app my-app migration-run --prepare migration-id"},
+			Description: `Run a migration step.
+
+With --all-steps, the preflight, prepare, data and finalize steps are chained, starting from the current status of the migration. An interrupted migration is resumed by running the command again.
+The migration ID is optional with --all-steps: the ongoing migration of the app is used, or a new one is created if --to is given. With --yes, the steps are not confirmed.
+
+With --apps-from, the migrations of all the apps listed in the file are run with a bounded concurrency, and a report of each migration is displayed and optionally written to a JSON file.`,
+			Examples: []string{
+				"scalingo --app my-app migration-run --prepare migration-id",
+				"scalingo --app my-app migration-run --all-steps migration-id",
+				"scalingo --app my-app migration-run --all-steps --yes --to osc-secnum-fr1",
+				"scalingo migration-run --all-steps --yes --to osc-secnum-fr1 --apps-from apps.txt --concurrency 5 --report report.json",
+			},
+			SeeAlso: []string{"migration-create", "migration-abort", "migrations"},
 		}.Render(),
 
 		Action: func(c *cli.Context) error {
+			if c.Bool("all-steps") {
+				return migrationRunAllSteps(c)
+			}
+
 			if c.Args().Len() != 1 {
 				cli.ShowCommandHelp(c, "migration-run")
 				return nil
@@ -175,3 +232,42 @@ var (
 		},
 	}
 )
+
+func migrationRunAllSteps(c *cli.Context) error {
+	if c.Bool("prepare") || c.Bool("data") || c.Bool("finalize") || c.Args().Len() > 1 {
+		return cli.ShowCommandHelp(c, "migration-run")
+	}
+
+	if c.String("apps-from") != "" {
+		if !c.Bool("yes") || c.Args().Len() != 0 {
+			errorQuitWithHelpMessage(errors.New("the batch mode requires --yes and no migration ID"), c, "migration-run")
+		}
+		apps, err := utils.ReadAppsFile(c.String("apps-from"))
+		if err != nil {
+			errorQuit(err)
+		}
+		err = regionmigrations.RunBatch(c.Context, regionmigrations.BatchOpts{
+			Apps:        apps,
+			Destination: c.String("to"),
+			Concurrency: c.Int("concurrency"),
+			ReportFile:  c.String("report"),
+		})
+		if err != nil {
+			errorQuit(err)
+		}
+		return nil
+	}
+
+	currentApp := detect.CurrentApp(c)
+	utils.CheckForConsent(c.Context, currentApp)
+
+	err := regionmigrations.RunAllSteps(c.Context, currentApp, regionmigrations.RunAllStepsOpts{
+		MigrationID: c.Args().First(),
+		Destination: c.String("to"),
+		Yes:         c.Bool("yes"),
+	})
+	if err != nil {
+		errorQuit(err)
+	}
+	return nil
+}
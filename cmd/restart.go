@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"errors"
+	"time"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
@@ -16,21 +19,47 @@ var (
 		Usage:    "Restart processes of your app",
 		Flags: []cli.Flag{&appFlag,
 			&cli.BoolFlag{Name: "synchronous", Aliases: []string{"s"}, Usage: "Do the restart synchronously"},
+			&cli.BoolFlag{Name: "async", Usage: "Only print the ID of the restart operation, to wait for it later with 'operation-wait'"},
+			&cli.BoolFlag{Name: "rolling", Usage: "Restart the containers by batches, waiting for each batch to be running before the next one"},
+			&cli.IntFlag{Name: "batch", Usage: "Amount of containers restarted at once with --rolling", Value: 1},
+			&cli.DurationFlag{Name: "interval", Usage: "Time to wait between two batches with --rolling", Value: 30 * time.Second},
 		},
 		Description: CommandDescription{
-			Description: "Restart one or several process or your application",
+			Description: `Restart one or several process or your application
+
+With --rolling, the containers are restarted by batches of --batch containers. The next batch is restarted once the containers of the previous one are running again and --interval is elapsed, the web containers can be restarted without a visible dip.`,
 			Examples: []string{
 				"scalingo --app my-app restart        # Restart all the processes",
 				"scalingo --app my-app restart web    # Restart all the web processes",
 				"scalingo --app my-app restart web-1  # Restart a specific container",
+				"scalingo --app my-app restart --async web",
+				"scalingo --app my-app restart --rolling --batch 1 --interval 30s web",
 			},
+			SeeAlso: []string{"operations", "operation-wait"},
 		}.Render(),
 
 		Action: func(c *cli.Context) error {
 			currentApp := detect.CurrentApp(c)
 			utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeContainers)
 
-			if err := apps.Restart(c.Context, currentApp, c.Bool("s"), c.Args().Slice()); err != nil {
+			if c.Bool("s") && c.Bool("async") {
+				errorQuitWithHelpMessage(errors.New("--synchronous and --async cannot be used together"), c, "restart")
+			}
+			if c.Bool("rolling") && (c.Bool("s") || c.Bool("async")) {
+				errorQuitWithHelpMessage(errors.New("--rolling cannot be used with --synchronous or --async"), c, "restart")
+			}
+			if c.Bool("rolling") && c.Int("batch") < 1 {
+				errorQuitWithHelpMessage(errors.New("--batch must be at least 1"), c, "restart")
+			}
+
+			opts := apps.RestartOpts{
+				Sync:     c.Bool("s"),
+				Async:    c.Bool("async"),
+				Rolling:  c.Bool("rolling"),
+				Batch:    c.Int("batch"),
+				Interval: c.Duration("interval"),
+			}
+			if err := apps.Restart(c.Context, currentApp, opts, c.Args().Slice()); err != nil {
 				errorQuit(err)
 			}
 			return nil
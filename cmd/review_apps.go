@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"strconv"
+
 	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/reviewapps"
+	"github.com/Scalingo/cli/utils"
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/detect"
@@ -13,11 +17,21 @@ var (
 	reviewAppsShowCommand = cli.Command{
 		Name:     "review-apps",
 		Category: "Review Apps",
-		Flags:    []cli.Flag{&appFlag},
-		Usage:    "Show review apps of the parent application",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "all", Usage: "Show the review apps of all the applications linked to a repository"},
+			&cli.StringFlag{Name: "state", Usage: "Only show the review apps of pull requests in this state (open or closed)"},
+			&cli.StringFlag{Name: "older-than", Usage: "Only show the review apps created before this period (e.g. 7d, 12h)"},
+		},
+		Usage: "Show review apps of the parent application",
 		Description: CommandDescription{
-			Description: "Show review apps of the parent application",
-			Examples:    []string{"scalingo --app my-app review-apps"},
+			Description: `Show review apps of the parent application.
+With --all, the review apps of all the applications linked to a repository are shown.`,
+			Examples: []string{
+				"scalingo --app my-app review-apps",
+				"scalingo review-apps --all --state closed",
+				"scalingo review-apps --all --older-than 30d",
+			},
+			SeeAlso: []string{"review-apps-destroy", "review-apps-open"},
 		}.Render(),
 		Action: func(c *cli.Context) error {
 			if c.Args().Len() != 0 {
@@ -25,8 +39,11 @@ var (
 				return nil
 			}
 
-			currentApp := detect.CurrentApp(c)
-			err := reviewapps.Show(c.Context, currentApp)
+			opts, err := reviewAppsListOptsFromFlags(c)
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "review-apps")
+			}
+			err = reviewapps.List(c.Context, opts)
 			if err != nil {
 				errorQuit(err)
 			}
@@ -36,4 +53,187 @@ var (
 			_ = autocomplete.CmdFlagsAutoComplete(c, "review-apps")
 		},
 	}
+
+	reviewAppsDestroyCommand = cli.Command{
+		Name:     "review-apps-destroy",
+		Category: "Review Apps",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "all", Usage: "Destroy review apps of all the applications linked to a repository"},
+			&cli.BoolFlag{Name: "closed", Usage: "Destroy the review apps of closed pull requests"},
+			&cli.StringFlag{Name: "older-than", Usage: "Destroy the review apps created before this period (e.g. 7d, 12h)"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only list the review apps which would be destroyed"},
+			&cli.BoolFlag{Name: "force", Usage: "Destroy without asking for a confirmation /!\\"},
+		},
+		Usage: "Destroy the stale review apps of the parent application",
+		Description: CommandDescription{
+			Description: `Destroy the review apps of closed pull requests and/or the ones older than a given period.
+At least one of --closed and --older-than is required. With --all, the review apps of all the applications linked to a repository are considered.`,
+			Examples: []string{
+				"scalingo --app my-app review-apps-destroy --closed",
+				"scalingo review-apps-destroy --all --closed --older-than 7d --dry-run",
+			},
+			SeeAlso: []string{"review-apps"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 || (!c.Bool("closed") && c.String("older-than") == "") {
+				cli.ShowCommandHelp(c, "review-apps-destroy")
+				return nil
+			}
+
+			opts, err := reviewAppsListOptsFromFlags(c)
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "review-apps-destroy")
+			}
+			if c.Bool("closed") {
+				opts.Filter.State = reviewapps.PullRequestStateClosed
+			}
+
+			err = reviewapps.Destroy(c.Context, reviewapps.DestroyOpts{
+				ListOpts: opts,
+				DryRun:   c.Bool("dry-run"),
+				Force:    c.Bool("force"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			_ = autocomplete.CmdFlagsAutoComplete(c, "review-apps-destroy")
+		},
+	}
+
+	reviewAppCreateCommand = cli.Command{
+		Name:     "review-app-create",
+		Category: "Review Apps",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "parent", Usage: "Parent application of the review app, the current application by default"},
+			&cli.StringFlag{Name: "name", Usage: "Name of the review app", Required: true},
+			&cli.BoolFlag{Name: "no-follow", Usage: "Do not follow the deployment logs"},
+		},
+		Usage: "Create a review app of the current Git branch without SCM integration",
+		Description: CommandDescription{
+			Description: `Create a review app of the current Git branch, for applications which are not linked to GitHub or GitLab.
+The configuration of the parent application is copied: addons plans, environment variables and collaborators.
+The last commit of the current branch is then deployed with an archive, and the review app is scaled like its parent once deployed.
+With --no-follow, the deployment is not awaited and the scale command to run once it is done is printed instead.
+
+The environment variables already defined by the addons of the review app are not overwritten, and the ones generated by the addons of the parent application are never copied. The elements which could not be copied are listed.
+The review app is tracked locally and can be destroyed with 'review-app-destroy'.`,
+			Examples: []string{
+				"scalingo review-app-create --parent my-app --name my-app-feature-x",
+			},
+			SeeAlso: []string{"review-app-destroy", "integration-link-manual-review-app"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "review-app-create")
+				return nil
+			}
+
+			parent := c.String("parent")
+			if parent == "" {
+				parent = detect.CurrentApp(c)
+			}
+			err := reviewapps.Create(c.Context, reviewapps.CreateOpts{
+				Parent:   parent,
+				Name:     c.String("name"),
+				NoFollow: c.Bool("no-follow"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			_ = autocomplete.CmdFlagsAutoComplete(c, "review-app-create")
+		},
+	}
+
+	reviewAppDestroyCommand = cli.Command{
+		Name:      "review-app-destroy",
+		Category:  "Review Apps",
+		Flags:     []cli.Flag{&cli.BoolFlag{Name: "force", Usage: "Destroy without asking for a confirmation /!\\"}},
+		Usage:     "Destroy a review app created with review-app-create",
+		ArgsUsage: "review-app-name",
+		Description: CommandDescription{
+			Description: "Destroy a review app created with 'review-app-create', with its addons, and forget its link with the parent application",
+			Examples:    []string{"scalingo review-app-destroy my-app-feature-x"},
+			SeeAlso:     []string{"review-app-create"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "review-app-destroy")
+				return nil
+			}
+
+			err := reviewapps.DestroyLocal(c.Context, c.Args().First(), c.Bool("force"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			_ = autocomplete.CmdFlagsAutoComplete(c, "review-app-destroy")
+		},
+	}
+
+	reviewAppsOpenCommand = cli.Command{
+		Name:      "review-apps-open",
+		Category:  "Review Apps",
+		Flags:     []cli.Flag{&appFlag},
+		Usage:     "Open the review app of a pull request in your browser",
+		ArgsUsage: "pull-request-number",
+		Description: CommandDescription{
+			Description: "Open the review app of a pull request of the parent application in your browser",
+			Examples:    []string{"scalingo --app my-app review-apps-open 42"},
+			SeeAlso:     []string{"review-apps"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "review-apps-open")
+				return nil
+			}
+			pullRequest, err := strconv.Atoi(c.Args().First())
+			if err != nil {
+				errorQuitWithHelpMessage(errgo.Newf("invalid pull request number '%s'", c.Args().First()), c, "review-apps-open")
+			}
+
+			currentApp := detect.CurrentApp(c)
+			err = reviewapps.Open(c.Context, currentApp, pullRequest)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			_ = autocomplete.CmdFlagsAutoComplete(c, "review-apps-open")
+		},
+	}
 )
+
+func reviewAppsListOptsFromFlags(c *cli.Context) (reviewapps.ListOpts, error) {
+	opts := reviewapps.ListOpts{
+		All: c.Bool("all"),
+	}
+	if !opts.All {
+		opts.App = detect.CurrentApp(c)
+	}
+
+	if c.IsSet("state") {
+		opts.Filter.State = c.String("state")
+		err := reviewapps.ValidateState(opts.Filter.State)
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	if c.String("older-than") != "" {
+		olderThan, err := utils.ParseDuration(c.String("older-than"))
+		if err != nil {
+			return opts, err
+		}
+		opts.Filter.OlderThan = olderThan
+	}
+	return opts, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/detect"
 	"github.com/Scalingo/cli/io"
 	"github.com/Scalingo/cli/utils"
@@ -23,7 +24,11 @@ var (
 			&cli.StringFlag{Name: "type", Aliases: []string{"t"}, Value: "", Usage: "Procfile Type"},
 			&cli.StringSliceFlag{Name: "env", Aliases: []string{"e"}, Usage: "Environment variables"},
 			&cli.StringSliceFlag{Name: "file", Aliases: []string{"f"}, Usage: "Files to upload"},
+			&cli.StringSliceFlag{Name: "download", Usage: "Files or directories to download once the command is done (remote:local)"},
 			&cli.BoolFlag{Name: "silent", Usage: "Do not output anything on stderr"},
+			&cli.BoolFlag{Name: "record", Usage: "Record the session in the recordings directory (default from 'scalingo config --record-runs')"},
+			&cli.BoolFlag{Name: "no-tty", Usage: "Do not handle the terminal, for the scripts piping data to the command"},
+			&cli.DurationFlag{Name: "timeout", Usage: "Stop the one-off if the command is not done after this duration (e.g. 30m)"},
 		},
 		Description: `Run command in current app context, a one-off container will be
    start with your application environment loaded.
@@ -75,7 +80,40 @@ var (
    '/tmp/uploads' directory of the one-off container. Each file size cannot exceed 100 MiB.
 
    Example
-     scalingo run --file mysqldump.sql rails dbconsole < /tmp/uploads/mysqldump.sql`,
+     scalingo run --file mysqldump.sql rails dbconsole < /tmp/uploads/mysqldump.sql
+
+   The opposite is possible with the option '--download remote:local': once the
+   command is done, the remote file or directory is packed, sent over the
+   connection to the one-off and written to the local path. Its integrity is
+   checked with a SHA-256 checksum. The option can be used multiple times.
+
+   Example
+     scalingo run --download /app/tmp/report.csv:./report.csv rake reports:generate
+
+   The --record flag records the output of the session with its timing in the
+   asciicast v2 format, in the run_recordings directory of the configuration
+   directory (RUN_RECORDINGS_DIR environment variable). The recording of all the
+   sessions can be enabled by default with 'scalingo config --record-runs'. The
+   recordings are played back with the command 'run-replay'.
+
+   Example
+     scalingo --app my-app run --record bash
+     scalingo run-replay ~/.config/scalingo/run_recordings/my-app-20240102-150405.cast
+
+   The --no-tty flag is meant for scripts: the local terminal is not switched to
+   raw mode, the standard input is streamed as is until its end and the
+   connection is then half-closed, without sending an EOT character. The output
+   of the command is written on stdout while the messages of the command tool
+   are written on stderr. The one-off is still attached to a terminal on the
+   Scalingo side: the stderr of the remote command is merged into its stdout and
+   cannot be consumed separately, redirect it in the command itself if needed
+   (e.g. 'run --no-tty "rake import 2> /tmp/errors.log"'), and the remote
+   terminal may echo the input in the output. The exit status is the exit code
+   of the remote command. The --timeout flag stops the one-off if its command is
+   not done in time, the exit status is then 1.
+
+   Example
+     cat data.csv | scalingo --app my-app run --no-tty --silent --timeout 10m bundle exec rake import:csv > import.log`,
 		Action: func(c *cli.Context) error {
 			currentApp := detect.CurrentApp(c)
 			opts := apps.RunOpts{
@@ -88,6 +126,12 @@ var (
 				Silent:   c.Bool("silent"),
 				Detached: c.Bool("detached"),
 				Async:    true,
+				Record:   config.C.RecordRuns,
+				NoTTY:    c.Bool("no-tty"),
+				Timeout:  c.Duration("timeout"),
+			}
+			if c.IsSet("record") {
+				opts.Record = c.Bool("record")
 			}
 			if (c.Args().Len() == 0 && c.String("t") == "") || (c.Args().Len() > 0 && c.String("t") != "") {
 				cli.ShowCommandHelp(c, "run")
@@ -99,8 +143,26 @@ var (
 				return nil
 			}
 
+			for _, value := range c.StringSlice("download") {
+				download, err := apps.ParseRunDownload(value)
+				if err != nil {
+					errorQuitWithHelpMessage(err, c, "run")
+				}
+				opts.Downloads = append(opts.Downloads, download)
+			}
+			if opts.Detached && len(opts.Downloads) > 0 {
+				io.Error("It is impossible to download files from a detached one-off. Please either remove the --detached or --download flags.")
+				return nil
+			}
+
+			if opts.Detached && opts.Timeout > 0 {
+				io.Error("It is impossible to set a timeout to a detached one-off. Please either remove the --detached or --timeout flags.")
+				return nil
+			}
+
 			utils.CheckForConsent(c.Context, currentApp)
 
+			opts.ExitFunc = exitWithCode
 			err := apps.Run(c.Context, opts)
 			if err != nil {
 				errorQuit(err)
@@ -111,4 +173,42 @@ var (
 			autocomplete.CmdFlagsAutoComplete(c, "run")
 		},
 	}
+
+	runReplayCommand = cli.Command{
+		Name:      "run-replay",
+		Category:  "App Management",
+		Usage:     "Play back a one-off session recorded with 'run --record'",
+		ArgsUsage: "recording-file",
+		Flags: []cli.Flag{
+			&cli.Float64Flag{Name: "speed", Value: 1, Usage: "Speed of the replay (2 plays it twice as fast)"},
+			&cli.DurationFlag{Name: "max-idle", Usage: "Maximum time between two outputs (e.g. 2s), no limit by default"},
+		},
+		Description: CommandDescription{
+			Description: `Play back a one-off session recorded with 'run --record'. The command, the user, the app and the size of the container are displayed first.
+The recordings are in the asciicast v2 format and can also be played with asciinema.`,
+			Examples: []string{
+				"scalingo run-replay ~/.config/scalingo/run_recordings/my-app-20240102-150405.cast",
+				"scalingo run-replay --speed 2 --max-idle 1s session.cast",
+			},
+			SeeAlso: []string{"run"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				return cli.ShowCommandHelp(c, "run-replay")
+			}
+
+			err := apps.RunReplay(c.Context, apps.RunReplayOpts{
+				File:    c.Args().First(),
+				Speed:   c.Float64("speed"),
+				MaxIdle: c.Duration("max-idle"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "run-replay")
+		},
+	}
 )
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/scaling"
 	"github.com/Scalingo/cli/utils"
 )
 
@@ -16,6 +20,9 @@ var (
 		Category: "App Management",
 		Flags: []cli.Flag{&appFlag,
 			&cli.BoolFlag{Name: "synchronous", Aliases: []string{"s"}, Usage: "Do the scaling synchronously"},
+			&cli.BoolFlag{Name: "async", Usage: "Only print the ID of the scaling operation, to wait for it later with 'operation-wait'"},
+			&cli.StringFlag{Name: "preset", Usage: "Scale to the formation saved with 'scale-preset save'"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only print the scaling parameters and the changes of the formation, without scaling"},
 		},
 		Usage:     "Scale your application instantly",
 		ArgsUsage: "[scaling-instruction...]",
@@ -26,13 +33,29 @@ var (
 				"scalingo --app my-app scale web:1 worker:0",
 				"scalingo --app my-app scale web:1:XL",
 				"scalingo --app my-app scale web:+1 worker:-1",
+				"scalingo --app my-app scale --async web:2",
+				"scalingo --app my-app scale --preset night",
+				"scalingo --app my-app scale --dry-run web:2:L",
 			},
+			SeeAlso: []string{"operations", "operation-wait", "scale-preset", "scale-schedule"},
 		}.Render(),
 		Action: func(c *cli.Context) error {
 			currentApp := detect.CurrentApp(c)
 			utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeContainers)
 
-			if c.Args().Len() == 0 {
+			types := c.Args().Slice()
+			if c.IsSet("preset") {
+				if len(types) > 0 {
+					errorQuitWithHelpMessage(errors.New("--preset cannot be used with scaling instructions"), c, "scale")
+				}
+				preset, err := scaling.FindPreset(config.C.ScalingoRegion, currentApp, c.String("preset"))
+				if err != nil {
+					errorQuit(err)
+				}
+				types = preset.Types()
+			}
+
+			if len(types) == 0 {
 				err := apps.ContainerTypes(c.Context, currentApp)
 				if err != nil {
 					errorQuit(err)
@@ -40,7 +63,15 @@ var (
 				return nil
 			}
 
-			err := apps.Scale(c.Context, currentApp, c.Bool("s"), c.Args().Slice())
+			if c.Bool("s") && c.Bool("async") {
+				errorQuitWithHelpMessage(errors.New("--synchronous and --async cannot be used together"), c, "scale")
+			}
+
+			err := apps.Scale(c.Context, currentApp, apps.ScaleOpts{
+				Sync:   c.Bool("s"),
+				Async:  c.Bool("async"),
+				DryRun: c.Bool("dry-run"),
+			}, types)
 			if err != nil {
 				errorQuit(err)
 			}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/scaling"
+)
+
+var (
+	scalePresetCommand = cli.Command{
+		Name:      "scale-preset",
+		Category:  "App Management",
+		Usage:     "Save or delete a named formation of your application",
+		ArgsUsage: "save|delete preset-name",
+		Flags:     []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: `Save the current formation of your application as a named preset, or delete a preset.
+The application is scaled to a preset with 'scale --preset', the presets are kept on this computer.`,
+			Examples: []string{
+				"scalingo --app my-app scale-preset save night",
+				"scalingo --app my-app scale-preset delete night",
+			},
+			SeeAlso: []string{"scale-presets", "scale", "scale-schedule"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 2 {
+				cli.ShowCommandHelp(c, "scale-preset")
+				return nil
+			}
+
+			var err error
+			name := c.Args().Get(1)
+			switch c.Args().First() {
+			case "save":
+				err = scaling.PresetSave(c.Context, currentApp, name)
+			case "delete":
+				err = scaling.PresetDelete(currentApp, name)
+			default:
+				cli.ShowCommandHelp(c, "scale-preset")
+				return nil
+			}
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "scale-preset")
+		},
+	}
+
+	scalePresetsCommand = cli.Command{
+		Name:     "scale-presets",
+		Category: "App Management",
+		Usage:    "List the formation presets of your application",
+		Flags:    []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: "List the formation presets of your application saved with 'scale-preset save'",
+			Examples:    []string{"scalingo --app my-app scale-presets"},
+			SeeAlso:     []string{"scale-preset", "scale"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "scale-presets")
+				return nil
+			}
+
+			err := scaling.Presets(currentApp)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "scale-presets")
+		},
+	}
+)
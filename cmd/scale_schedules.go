@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/scaling"
+)
+
+var (
+	scaleScheduleCommand = cli.Command{
+		Name:      "scale-schedule",
+		Category:  "App Management",
+		Usage:     "Schedule the scaling of your application to a preset",
+		ArgsUsage: "add cron-expression preset-name | remove schedule-number",
+		Flags:     []cli.Flag{&appFlag},
+		Description: CommandDescription{
+			Description: `Schedule the scaling of your application to a preset saved with 'scale-preset save', at the times matching a cron expression (minute hour day-of-month month day-of-week).
+The schedules are applied by the long-running 'scalingo scheduler' process, or by crontab with the lines printed by 'scalingo scale-schedules --crontab'.`,
+			Examples: []string{
+				"scalingo --app my-app scale-schedule add '0 8 * * 1-5' day",
+				"scalingo --app my-app scale-schedule add '0 20 * * *' night",
+				"scalingo --app my-app scale-schedule remove 2",
+			},
+			SeeAlso: []string{"scale-schedules", "scheduler", "scale-preset"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+
+			var err error
+			switch {
+			case c.Args().First() == "add" && c.Args().Len() == 3:
+				err = scaling.ScheduleAdd(currentApp, c.Args().Get(1), c.Args().Get(2))
+			case c.Args().First() == "remove" && c.Args().Len() == 2:
+				number, convErr := strconv.Atoi(c.Args().Get(1))
+				if convErr != nil {
+					errorQuitWithHelpMessage(errgo.Newf("invalid schedule number '%s'", c.Args().Get(1)), c, "scale-schedule")
+				}
+				err = scaling.ScheduleRemove(currentApp, number)
+			default:
+				cli.ShowCommandHelp(c, "scale-schedule")
+				return nil
+			}
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "scale-schedule")
+		},
+	}
+
+	scaleSchedulesCommand = cli.Command{
+		Name:     "scale-schedules",
+		Category: "App Management",
+		Usage:    "List the scale schedules of your application",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "all", Usage: "List the schedules of all the applications of all the regions"},
+			&cli.BoolFlag{Name: "crontab", Usage: "Print the schedules as crontab lines"},
+		},
+		Description: CommandDescription{
+			Description: "List the scale schedules of your application, or print them as crontab lines to apply them with cron instead of 'scalingo scheduler'",
+			Examples: []string{
+				"scalingo --app my-app scale-schedules",
+				"scalingo scale-schedules --all --crontab | crontab -",
+			},
+			SeeAlso: []string{"scale-schedule", "scheduler"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "scale-schedules")
+				return nil
+			}
+			opts := scaling.ListSchedulesOpts{
+				All:     c.Bool("all"),
+				Crontab: c.Bool("crontab"),
+			}
+			if !opts.All {
+				opts.App = detect.CurrentApp(c)
+			}
+
+			err := scaling.Schedules(opts)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "scale-schedules")
+		},
+	}
+
+	schedulerCommand = cli.Command{
+		Name:     "scheduler",
+		Category: "App Management",
+		Usage:    "Apply the scale schedules of all your applications when they are due",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only print the scaling parameters when a schedule is due, without scaling"},
+		},
+		Description: CommandDescription{
+			Description: `Long-running process applying the scale schedules of all the applications when they are due.
+The schedules are read every minute, they can be changed without restarting the scheduler.`,
+			Examples: []string{
+				"scalingo scheduler",
+				"scalingo scheduler --dry-run",
+			},
+			SeeAlso: []string{"scale-schedule", "scale-schedules"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "scheduler")
+				return nil
+			}
+
+			err := scaling.RunScheduler(c.Context, scaling.SchedulerOpts{DryRun: c.Bool("dry-run")})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "scheduler")
+		},
+	}
+)
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/tasks"
+	"github.com/Scalingo/cli/utils"
+)
+
+var (
+	taskCommand = cli.Command{
+		Name:      "task",
+		Category:  "App Management",
+		Usage:     "Run a task defined in the repository in a one-off container",
+		ArgsUsage: "task-name [args]",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "list", Aliases: []string{"l"}, Usage: "List the tasks defined in the repository"},
+			&cli.BoolFlag{Name: "force", Usage: "Run the task without asking for a confirmation"},
+		},
+		Description: CommandDescription{
+			Description: `Run a task defined in the repository in a one-off container
+
+The tasks are defined in the 'tasks' key of the scalingo.json file or of the
+.scalingo/tasks.yml file, in the current directory or in one of its parents:
+
+==== .scalingo/tasks.yml
+tasks:
+  migrate:
+    description: Migrate the database
+    command: bundle exec rake db:migrate
+    size: XL
+    env:
+      VERBOSE: "true"
+    files: [db/seeds.csv]
+    confirm_apps: ["*-production"]
+====
+
+The arguments following the task name are appended to its command. The files
+are relative to the directory of the tasks file and uploaded in the
+/tmp/uploads directory of the one-off container. The task is only run after a
+confirmation on the apps matching one of the confirm_apps patterns.`,
+			Examples: []string{
+				"scalingo task --list",
+				"scalingo --app my-app task migrate",
+				"scalingo --app my-app task migrate VERSION=20240102150405",
+			},
+			SeeAlso: []string{"run"},
+		}.Render(),
+		Action: func(c *cli.Context) error {
+			definedTasks, err := tasks.Load()
+			if err != nil {
+				errorQuit(err)
+			}
+			if c.Bool("list") {
+				tasks.Display(definedTasks)
+				return nil
+			}
+			if c.Args().Len() == 0 {
+				cli.ShowCommandHelp(c, "task")
+				return nil
+			}
+
+			currentApp := detect.CurrentApp(c)
+			task, err := tasks.Find(definedTasks, c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+
+			if task.NeedsConfirmation(currentApp) && !c.Bool("force") {
+				confirmed := false
+				err = survey.AskOne(&survey.Confirm{
+					Message: fmt.Sprintf("Run the task %s (%s) on %s?", task.Name, task.Command, currentApp),
+				}, &confirmed)
+				if err != nil {
+					errorQuit(err)
+				}
+				if !confirmed {
+					io.Status("The task has not been run")
+					return nil
+				}
+			}
+
+			utils.CheckForConsent(c.Context, currentApp)
+
+			runOpts := task.RunOpts(currentApp, c.Args().Tail())
+			runOpts.ExitFunc = exitWithCode
+			err = apps.Run(c.Context, runOpts)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "task")
+			autocomplete.TaskNamesAutoComplete(c)
+		},
+	}
+)
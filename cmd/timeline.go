@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"strings"
+	"time"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/events"
 	"github.com/Scalingo/cli/utils"
 	"github.com/Scalingo/go-scalingo/v6"
 )
@@ -14,15 +18,19 @@ var (
 	TimelineCommand = cli.Command{
 		Name:     "timeline",
 		Category: "Events",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&appFlag,
 			&cli.IntFlag{Name: "page", Usage: "Page to display", Value: 1},
 			&cli.IntFlag{Name: "per-page", Usage: "Number of events to display", Value: 30},
-		},
+		}, timelineFilterFlags...),
 		Usage: "List the actions related to a given app",
 		Description: CommandDescription{
-			Description: "List the actions done by the owner and collaborators of an app",
-			Examples:    []string{"scalingo --app my-app timeline"},
+			Description: "List the actions done by the owner and collaborators of an app.\n\n" + timelineFilterDescription,
+			Examples: []string{
+				"scalingo --app my-app timeline",
+				"scalingo --app my-app timeline --type edit_variables,new_variable,delete_variable --since 30d",
+				"scalingo --app my-app timeline --user john@example.com --since 2023-03-01 --until 2023-03-31 --format csv > events.csv",
+			},
 		}.Render(),
 
 		Action: func(c *cli.Context) error {
@@ -32,11 +40,13 @@ var (
 				return nil
 			}
 
+			opts, err := timelineOptsFromFlags(c)
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "timeline")
+			}
+
 			utils.CheckForConsent(c.Context, currentApp)
-			err := apps.Events(c.Context, currentApp, scalingo.PaginationOpts{
-				Page:    c.Int("page"),
-				PerPage: c.Int("per-page"),
-			})
+			err = apps.Events(c.Context, currentApp, opts)
 			if err != nil {
 				errorQuit(err)
 			}
@@ -48,3 +58,67 @@ var (
 		},
 	}
 )
+
+var (
+	timelineFilterFlags = []cli.Flag{
+		&cli.StringSliceFlag{Name: "type", Usage: "Only display the events of these types (e.g. edit_variables, scale, deployment)"},
+		&cli.StringSliceFlag{Name: "user", Usage: "Only display the events done by these users (username or email)"},
+		&cli.StringFlag{Name: "since", Usage: "Only display the events created after this date (YYYY-MM-DD, RFC 3339 or a duration like 30d)"},
+		&cli.StringFlag{Name: "until", Usage: "Only display the events created before this date (YYYY-MM-DD, RFC 3339 or a duration like 30d)"},
+		&cli.BoolFlag{Name: "all", Usage: "Display the events of all the pages"},
+		&cli.StringFlag{Name: "format", Usage: "Output format: text, json (JSON Lines) or csv", Value: string(events.FormatText)},
+	}
+	timelineFilterDescription = `With a filter (--type, --user, --since or --until) or with --all, all the pages are fetched and the matching events are displayed at once.`
+)
+
+func timelineOptsFromFlags(c *cli.Context) (events.TimelineOpts, error) {
+	opts := events.TimelineOpts{
+		Pagination: scalingo.PaginationOpts{
+			Page:    c.Int("page"),
+			PerPage: c.Int("per-page"),
+		},
+		All:    c.Bool("all"),
+		Format: events.Format(c.String("format")),
+	}
+
+	for _, t := range splitFlagValues(c.StringSlice("type")) {
+		opts.Filter.Types = append(opts.Filter.Types, scalingo.EventTypeName(t))
+	}
+	opts.Filter.Users = splitFlagValues(c.StringSlice("user"))
+
+	now := time.Now()
+	if c.String("since") != "" {
+		since, err := events.ParseDate(c.String("since"), now)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filter.Since = since
+	}
+	if c.String("until") != "" {
+		until, err := events.ParseDate(c.String("until"), now)
+		if err != nil {
+			return opts, err
+		}
+		// A day given as upper bound includes the whole day
+		if _, err := time.Parse("2006-01-02", c.String("until")); err == nil {
+			until = until.AddDate(0, 0, 1)
+		}
+		opts.Filter.Until = until
+	}
+	return opts, nil
+}
+
+// splitFlagValues splits the comma separated values of a slice flag which
+// can also be repeated
+func splitFlagValues(values []string) []string {
+	res := []string{}
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
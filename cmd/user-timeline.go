@@ -5,34 +5,37 @@ import (
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/user"
-	"github.com/Scalingo/go-scalingo/v6"
 )
 
 var (
 	UserTimelineCommand = cli.Command{
 		Name:     "user-timeline",
 		Category: "Events",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.IntFlag{Name: "page", Usage: "Page to display", Value: 1},
 			&cli.IntFlag{Name: "per-page", Usage: "Number of events to display", Value: 30},
-		},
+		}, timelineFilterFlags...),
 		Usage: "List the events you have done on the platform",
 		Description: CommandDescription{
-			Description: "List the events you have done on the platform",
-			Examples:    []string{"scalingo user-timeline --page 3 --per-page 20"},
+			Description: "List the events you have done on the platform.\n\n" + timelineFilterDescription,
+			Examples: []string{
+				"scalingo user-timeline --page 3 --per-page 20",
+				"scalingo user-timeline --type new_app,delete_app --since 2023-01-01 --format json",
+			},
 		}.Render(),
 
 		Action: func(c *cli.Context) error {
-			var err error
-			if c.Args().Len() == 0 {
-				err = user.Events(c.Context, scalingo.PaginationOpts{
-					Page:    c.Int("page"),
-					PerPage: c.Int("per-page"),
-				})
-			} else {
+			if c.Args().Len() != 0 {
 				cli.ShowCommandHelp(c, "user-timeline")
+				return nil
+			}
+
+			opts, err := timelineOptsFromFlags(c)
+			if err != nil {
+				errorQuitWithHelpMessage(err, c, "user-timeline")
 			}
 
+			err = user.Events(c.Context, opts)
 			if err != nil {
 				errorQuit(err)
 			}
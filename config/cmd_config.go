@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"strconv"
 
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
@@ -16,6 +17,24 @@ func SetRegion(ctx context.Context, regionName string) error {
 	}
 
 	C.ConfigFile.Region = region.Name
+	return writeConfigFile()
+}
+
+// SetAuditHook persists the command executed with each record of the audit
+// trail. An empty hook disables the forwarding of the records.
+func SetAuditHook(hook string) error {
+	C.ConfigFile.AuditHook = hook
+	return writeConfigFile()
+}
+
+// SetRecordRuns persists whether the sessions of the one-off containers are
+// recorded by default
+func SetRecordRuns(record bool) error {
+	C.ConfigFile.RecordRuns = record
+	return writeConfigFile()
+}
+
+func writeConfigFile() error {
 	fd, err := os.OpenFile(C.ConfigFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
 	if err != nil {
 		return errgo.Notef(err, "fail to open config file")
@@ -35,5 +54,7 @@ func Display() {
 	t.SetColWidth(60)
 	t.SetHeader([]string{"Configuration key", "Value"})
 	t.Append([]string{"region", C.ConfigFile.Region})
+	t.Append([]string{"audit_hook", C.ConfigFile.AuditHook})
+	t.Append([]string{"record_runs", strconv.FormatBool(C.ConfigFile.RecordRuns)})
 	t.Render()
 }
@@ -18,7 +18,9 @@ import (
 )
 
 type ConfigFile struct {
-	Region string `json:"region"`
+	Region     string `json:"region"`
+	AuditHook  string `json:"audit_hook,omitempty"`
+	RecordRuns bool   `json:"record_runs,omitempty"`
 }
 
 var (
@@ -46,6 +48,14 @@ type Config struct {
 	ConfigFilePath string `envconfig:"CONFIG_FILE_PATH"`
 	ConfigFile     ConfigFile
 
+	// Audit trail of the mutating commands
+	AuditLogFile string `envconfig:"AUDIT_LOG_FILE"`
+	AuditHook    string `envconfig:"SCALINGO_AUDIT_HOOK"`
+
+	// Recording of the one-off sessions
+	RecordRuns       bool   `envconfig:"SCALINGO_RECORD_RUNS"`
+	RunRecordingsDir string `envconfig:"RUN_RECORDINGS_DIR"`
+
 	// Cache related files
 	CacheDir         string `envconfig:"CACHE_DIR"`
 	RegionsCachePath string `envconfig:"REGIONS_CACHE_PATH"`
@@ -70,6 +80,8 @@ var (
 		"CONFIG_FILE_PATH":   "config.json",
 		"REGIONS_CACHE_PATH": "regions.json",
 		"LOG_FILE":           "local.log",
+		"AUDIT_LOG_FILE":     "audit.log",
+		"RUN_RECORDINGS_DIR": "run_recordings",
 	}
 	C         Config
 	TLSConfig *tls.Config
@@ -85,6 +97,8 @@ func init() {
 	env["AUTH_FILE"] = filepath.Join(env["CONFIG_DIR"], env["AUTH_FILE"])
 	env["CONFIG_FILE_PATH"] = filepath.Join(env["CONFIG_DIR"], env["CONFIG_FILE_PATH"])
 	env["LOG_FILE"] = filepath.Join(env["CONFIG_DIR"], env["LOG_FILE"])
+	env["AUDIT_LOG_FILE"] = filepath.Join(env["CONFIG_DIR"], env["AUDIT_LOG_FILE"])
+	env["RUN_RECORDINGS_DIR"] = filepath.Join(env["CONFIG_DIR"], env["RUN_RECORDINGS_DIR"])
 
 	env["CACHE_DIR"] = filepath.Join(home, env["CACHE_DIR"])
 	env["REGIONS_CACHE_PATH"] = filepath.Join(env["CACHE_DIR"], env["REGIONS_CACHE_PATH"])
@@ -138,6 +152,12 @@ func init() {
 	if C.ScalingoRegion == "" {
 		C.ScalingoRegion = C.ConfigFile.Region
 	}
+	if C.AuditHook == "" {
+		C.AuditHook = C.ConfigFile.AuditHook
+	}
+	if !C.RecordRuns {
+		C.RecordRuns = C.ConfigFile.RecordRuns
+	}
 }
 
 func (config Config) CurrentUser() (*scalingo.User, error) {
@@ -0,0 +1,115 @@
+package containersizes
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/costs"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// List displays the container sizes of all the regions, with their price and
+// whether they are available in the current region. The sizes of the other
+// regions are only used to know which ones are missing from the current
+// region, a region which cannot be reached is ignored.
+func List(ctx context.Context) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	sizes, err := c.ContainerSizesList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the container sizes")
+	}
+
+	sizes, available := mergeSizes(sizes, otherRegionsSizes(ctx))
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].Ordinal < sizes[j].Ordinal
+	})
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Name", "Memory", "Swap", "CPU", "Hourly Price", "Monthly Price", "Available in " + config.C.ScalingoRegion})
+	for _, size := range sizes {
+		availability := "no"
+		if available[size.Name] {
+			availability = "yes"
+		}
+		t.Append([]string{
+			size.Name,
+			humanize.IBytes(uint64(size.Memory)),
+			humanize.IBytes(uint64(size.Swap)),
+			size.HumanCPU,
+			costs.FormatPrice(size.HourlyPrice),
+			costs.FormatPrice(size.ThirtydaysPrice),
+			availability,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+// mergeSizes adds to the sizes of the current region the ones only available in
+// other regions, once each. The returned map tells whether a size is available
+// in the current region.
+func mergeSizes(sizes, otherRegionsSizes []scalingo.ContainerSize) ([]scalingo.ContainerSize, map[string]bool) {
+	available := map[string]bool{}
+	for _, size := range sizes {
+		available[size.Name] = true
+	}
+	for _, size := range otherRegionsSizes {
+		if _, ok := available[size.Name]; !ok {
+			available[size.Name] = false
+			sizes = append(sizes, size)
+		}
+	}
+	return sizes, available
+}
+
+// otherRegionsSizes returns the container sizes of the regions other than the
+// current one
+func otherRegionsSizes(ctx context.Context) []scalingo.ContainerSize {
+	regionsCache, err := config.EnsureRegionsCache(ctx, config.C, config.GetRegionOpts{})
+	if err != nil {
+		debug.Printf("[Container Sizes] Fail to list the regions: %v\n", err)
+		return nil
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		sizes []scalingo.ContainerSize
+	)
+	for _, region := range regionsCache.Regions {
+		if region.Name == config.C.ScalingoRegion {
+			continue
+		}
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			c, err := config.ScalingoClientForRegion(ctx, region)
+			if err != nil {
+				debug.Printf("[Container Sizes] Fail to get the client of %s: %v\n", region, err)
+				return
+			}
+			regionSizes, err := c.ContainerSizesList(ctx)
+			if err != nil {
+				debug.Printf("[Container Sizes] Fail to list the container sizes of %s: %v\n", region, err)
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			sizes = append(sizes, regionSizes...)
+		}(region.Name)
+	}
+	wg.Wait()
+	return sizes
+}
@@ -0,0 +1,22 @@
+package containersizes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestMergeSizes(t *testing.T) {
+	sizes := []scalingo.ContainerSize{{Name: "S"}, {Name: "M"}}
+	otherRegionsSizes := []scalingo.ContainerSize{
+		{Name: "S"}, {Name: "M"}, {Name: "2XL"},
+		{Name: "S"}, {Name: "M"}, {Name: "2XL"},
+	}
+
+	merged, available := mergeSizes(sizes, otherRegionsSizes)
+
+	assert.Equal(t, []scalingo.ContainerSize{{Name: "S"}, {Name: "M"}, {Name: "2XL"}}, merged)
+	assert.Equal(t, map[string]bool{"S": true, "M": true, "2XL": false}, available)
+}
@@ -0,0 +1,143 @@
+package containersizes
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// maxSuggestionDistance is the maximal edit distance between an unknown size
+// and the sizes suggested instead. It is lowered for the shortest sizes which
+// would otherwise be close to every size.
+const maxSuggestionDistance = 2
+
+// Validate checks that the sizes are container sizes of the region, the empty
+// sizes are ignored. If the sizes cannot be listed, the validation is left to
+// the Scalingo API.
+func Validate(ctx context.Context, c *scalingo.Client, sizes ...string) error {
+	if !hasSize(sizes) {
+		return nil
+	}
+
+	containerSizes, err := c.ContainerSizesList(ctx)
+	if err != nil {
+		debug.Printf("[Container Sizes] Fail to list the container sizes, skipping the validation: %v\n", err)
+		return nil
+	}
+	return ValidateWithList(containerSizes, sizes...)
+}
+
+// ValidateWithList checks that the sizes are part of the container sizes
+// already listed, the empty sizes are ignored
+func ValidateWithList(containerSizes []scalingo.ContainerSize, sizes ...string) error {
+	containerSizes = append([]scalingo.ContainerSize{}, containerSizes...)
+	sort.SliceStable(containerSizes, func(i, j int) bool {
+		return containerSizes[i].Ordinal < containerSizes[j].Ordinal
+	})
+
+	names := []string{}
+	for _, size := range containerSizes {
+		names = append(names, size.Name)
+	}
+	for _, size := range sizes {
+		if size == "" {
+			continue
+		}
+		err := validate(size, containerSizes, names)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+	}
+	return nil
+}
+
+func hasSize(sizes []string) bool {
+	for _, size := range sizes {
+		if size != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func validate(size string, containerSizes []scalingo.ContainerSize, names []string) error {
+	for _, containerSize := range containerSizes {
+		if containerSize.Name == size || containerSize.ID == size {
+			return nil
+		}
+	}
+
+	suggested := suggestions(size, names)
+	if len(suggested) > 0 {
+		return errgo.Newf("unknown container size '%s', did you mean %s?", size, joinOr(suggested))
+	}
+	return errgo.Newf(
+		"unknown container size '%s', the available sizes are %s (see 'scalingo container-sizes')",
+		size, strings.Join(names, ", "),
+	)
+}
+
+// suggestions returns the closest names to the unknown size, in the order of
+// the names. A name only differing by its case is the only suggestion.
+func suggestions(size string, names []string) []string {
+	maxDistance := len(size) / 2
+	if maxDistance > maxSuggestionDistance {
+		maxDistance = maxSuggestionDistance
+	}
+
+	suggested := []string{}
+	for _, name := range names {
+		if strings.EqualFold(name, size) {
+			return []string{name}
+		}
+		d := distance(strings.ToUpper(size), name)
+		if d < maxDistance {
+			maxDistance = d
+			suggested = []string{}
+		}
+		if d == maxDistance {
+			suggested = append(suggested, name)
+		}
+	}
+	return suggested
+}
+
+// joinOr joins the values as "A, B or C"
+func joinOr(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+// distance is the Levenshtein distance between a and b
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
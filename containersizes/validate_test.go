@@ -0,0 +1,41 @@
+package containersizes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestions(t *testing.T) {
+	names := []string{"XS", "S", "M", "L", "XL", "2XL", "3XL", "4XL"}
+
+	tests := map[string]struct {
+		size     string
+		expected []string
+	}{
+		"with a different case": {size: "xl", expected: []string{"XL"}},
+		"with a typo":           {size: "XXL", expected: []string{"XL", "2XL", "3XL", "4XL"}},
+		"with a closest size":   {size: "XL2", expected: []string{"XL"}},
+		"with a short size":     {size: "Z", expected: []string{}},
+		"without close size":    {size: "large", expected: []string{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, suggestions(test.size, names))
+		})
+	}
+}
+
+func TestJoinOr(t *testing.T) {
+	assert.Equal(t, "XL", joinOr([]string{"XL"}))
+	assert.Equal(t, "XL or 2XL", joinOr([]string{"XL", "2XL"}))
+	assert.Equal(t, "XL, 2XL or 3XL", joinOr([]string{"XL", "2XL", "3XL"}))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("XL", "XL"))
+	assert.Equal(t, 1, distance("XXL", "2XL"))
+	assert.Equal(t, 2, distance("XS", "M"))
+	assert.Equal(t, 3, distance("", "2XL"))
+}
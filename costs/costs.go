@@ -0,0 +1,203 @@
+package costs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// concurrency is the amount of applications estimated in parallel with --all
+const concurrency = 4
+
+// Line is an item of the monthly cost of an application: a container type or
+// an addon
+type Line struct {
+	Kind   string
+	Name   string
+	Amount int
+	// Detail is the container size or the addon plan
+	Detail string
+	Price  int
+	// Unknown is true if the price of the item could not be found
+	Unknown bool
+}
+
+// AppCosts is the estimated monthly cost of an application
+type AppCosts struct {
+	App        string
+	Lines      []Line
+	Containers int
+	Addons     int
+}
+
+func (c AppCosts) Total() int {
+	return c.Containers + c.Addons
+}
+
+// Incomplete returns true if the price of one of the items is unknown
+func (c AppCosts) Incomplete() bool {
+	for _, line := range c.Lines {
+		if line.Unknown {
+			return true
+		}
+	}
+	return false
+}
+
+// Estimate returns the monthly cost of the current formation and addons of
+// the application
+func Estimate(ctx context.Context, c *scalingo.Client, prices *Prices, app string) (AppCosts, error) {
+	costs := AppCosts{App: app}
+
+	formation, err := c.AppsContainerTypes(ctx, app)
+	if err != nil {
+		return costs, errgo.Notef(err, "fail to get the formation of %s", app)
+	}
+	for _, ct := range formation {
+		line := Line{Kind: "Containers", Name: ct.Name, Amount: ct.Amount, Detail: ct.Size}
+		price, ok := prices.ContainerSize(ct.Size)
+		if ok {
+			line.Price = price * ct.Amount
+			costs.Containers += line.Price
+		} else {
+			line.Unknown = ct.Amount > 0
+		}
+		costs.Lines = append(costs.Lines, line)
+	}
+
+	addons, err := c.AddonsList(ctx, app)
+	if err != nil {
+		return costs, errgo.Notef(err, "fail to list the addons of %s", app)
+	}
+	for _, addon := range addons {
+		if addon.AddonProvider == nil || addon.Plan == nil {
+			continue
+		}
+		line := Line{Kind: "Addon", Name: addon.AddonProvider.Name, Amount: 1, Detail: addon.Plan.DisplayName}
+		price, err := prices.Plan(ctx, addon.AddonProvider.ID, addon.Plan.ID)
+		if err == nil {
+			line.Price = price
+			costs.Addons += price
+		} else {
+			line.Unknown = true
+		}
+		costs.Lines = append(costs.Lines, line)
+	}
+	return costs, nil
+}
+
+// App displays the monthly cost of the application, item by item
+func App(ctx context.Context, app string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	prices, err := NewPrices(ctx, c)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	costs, err := Estimate(ctx, c, prices, app)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Kind", "Name", "Amount", "Size / Plan", "Monthly Price"})
+	t.SetFooter([]string{"", "", "", "Total", FormatPrice(costs.Total())})
+	for _, line := range costs.Lines {
+		price := FormatPrice(line.Price)
+		if line.Unknown {
+			price = "unknown"
+		}
+		t.Append([]string{line.Kind, line.Name, strconv.Itoa(line.Amount), line.Detail, price})
+	}
+	t.Render()
+	displayNotice(costs.Incomplete())
+	return nil
+}
+
+// All displays the monthly cost of all the applications of the region
+func All(ctx context.Context) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	prices, err := NewPrices(ctx, c)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	apps, err := c.AppsList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the apps")
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		semaphore = make(chan struct{}, concurrency)
+		appsCosts []AppCosts
+	)
+	for _, app := range apps {
+		wg.Add(1)
+		go func(app string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			costs, err := Estimate(ctx, c, prices, app)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				io.Warningf("Fail to estimate the cost of %s: %v\n", app, err)
+				return
+			}
+			appsCosts = append(appsCosts, costs)
+		}(app.Name)
+	}
+	wg.Wait()
+
+	sort.Slice(appsCosts, func(i, j int) bool {
+		return appsCosts[i].App < appsCosts[j].App
+	})
+
+	total := 0
+	incomplete := []string{}
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"App", "Containers", "Addons", "Monthly Price"})
+	for _, costs := range appsCosts {
+		name := costs.App
+		if costs.Incomplete() {
+			name += " (*)"
+			incomplete = append(incomplete, costs.App)
+		}
+		total += costs.Total()
+		t.Append([]string{name, FormatPrice(costs.Containers), FormatPrice(costs.Addons), FormatPrice(costs.Total())})
+	}
+	t.SetFooter([]string{"", "", "Total", FormatPrice(total)})
+	t.Render()
+	if len(incomplete) > 0 {
+		fmt.Printf("(*) The price of some items of %s is unknown\n", strings.Join(incomplete, ", "))
+	}
+	displayNotice(false)
+	return nil
+}
+
+func displayNotice(incomplete bool) {
+	if incomplete {
+		io.Warning("The price of some items is unknown, they are not part of the total")
+	}
+	io.Info("Estimation for 30 days excluding taxes, based on the public prices of the region")
+}
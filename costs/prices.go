@@ -0,0 +1,163 @@
+package costs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/http"
+)
+
+// Prices are the monthly prices of the container sizes and of the addon plans
+// of a region, in cents of euro. The plans of a provider are only fetched the
+// first time one of them is needed.
+type Prices struct {
+	client         *scalingo.Client
+	containerSizes map[string]int
+
+	plansMutex sync.Mutex
+	// plans are the prices of the plans by provider, nil if the price of the
+	// plan is unknown
+	plans map[string]map[string]*int
+}
+
+// planPrice is an addon plan with its price in euros. The price is not part of
+// the plans returned by the go-scalingo client, the plans are requested
+// directly. A plan without price has an unknown price, it is not free.
+type planPrice struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Price *float64 `json:"price"`
+}
+
+// providerTypos are the names of addon providers corrected by the
+// AddonProviderPlansList method of the go-scalingo client, they are corrected
+// the same way when the plans are requested directly
+var providerTypos = map[string]string{
+	"scalingo-mongo":    "scalingo-mongodb",
+	"scalingo-influx":   "scalingo-influxdb",
+	"scalingo-postgres": "scalingo-postgresql",
+	"scalingo-postgre":  "scalingo-postgresql",
+	"scalingo-pgsql":    "scalingo-postgresql",
+	"scalingo-psql":     "scalingo-postgresql",
+}
+
+// NewPrices fetches the prices of the container sizes
+func NewPrices(ctx context.Context, c *scalingo.Client) (*Prices, error) {
+	sizes, err := c.ContainerSizesList(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the container sizes")
+	}
+	return NewPricesFromSizes(c, sizes), nil
+}
+
+// NewPricesFromSizes returns the prices of the container sizes already listed
+func NewPricesFromSizes(c *scalingo.Client, sizes []scalingo.ContainerSize) *Prices {
+	prices := &Prices{
+		client:         c,
+		containerSizes: map[string]int{},
+		plans:          map[string]map[string]*int{},
+	}
+	for _, size := range sizes {
+		prices.containerSizes[size.Name] = size.ThirtydaysPrice
+		prices.containerSizes[size.ID] = size.ThirtydaysPrice
+	}
+	return prices
+}
+
+// ContainerSize returns the monthly price of a container of the size, false if
+// the size is unknown
+func (p *Prices) ContainerSize(size string) (int, bool) {
+	price, ok := p.containerSizes[size]
+	return price, ok
+}
+
+// Plan returns the monthly price of the plan of the addon provider. The plan is
+// designated by its ID or by its name.
+func (p *Prices) Plan(ctx context.Context, providerID, plan string) (int, error) {
+	p.plansMutex.Lock()
+	defer p.plansMutex.Unlock()
+
+	plans, ok := p.plans[providerID]
+	if !ok {
+		provider := providerID
+		if correctProvider, ok := providerTypos[provider]; ok {
+			provider = correctProvider
+		}
+		var res struct {
+			Plans []planPrice `json:"plans"`
+		}
+		err := p.client.ScalingoAPI().DoRequest(ctx, &http.APIRequest{
+			NoAuth:   true,
+			Endpoint: "/addon_providers/" + provider + "/plans",
+		}, &res)
+		if err != nil {
+			return 0, errgo.Notef(err, "fail to list the plans of %s", providerID)
+		}
+
+		plans = map[string]*int{}
+		for _, plan := range res.Plans {
+			var price *int
+			if plan.Price != nil {
+				cents := int(math.Round(*plan.Price * 100))
+				price = &cents
+			}
+			plans[plan.ID] = price
+			plans[plan.Name] = price
+		}
+		p.plans[providerID] = plans
+	}
+
+	price, ok := plans[plan]
+	if !ok {
+		return 0, errgo.Newf("unknown plan %s of %s", plan, providerID)
+	}
+	if price == nil {
+		return 0, errgo.Newf("the price of the plan %s of %s is unknown", plan, providerID)
+	}
+	return *price, nil
+}
+
+// FormationCost returns the monthly cost of the formation and the sizes of
+// which the price is unknown
+func FormationCost(prices *Prices, formation []scalingo.ContainerType) (int, []string) {
+	total := 0
+	unknown := []string{}
+	for _, ct := range formation {
+		price, ok := prices.ContainerSize(ct.Size)
+		if !ok {
+			if ct.Amount > 0 {
+				unknown = append(unknown, ct.Size)
+			}
+			continue
+		}
+		total += price * ct.Amount
+	}
+	return total, unknown
+}
+
+// FormatPrice formats a price in cents of euro
+func FormatPrice(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d €", sign, cents/100, cents%100)
+}
+
+// FormatPriceChange formats the change of a price: "12.00 € → 24.00 € (+12.00 €)"
+func FormatPriceChange(before, after int) string {
+	if before == after {
+		return FormatPrice(after)
+	}
+	sign := "+"
+	if after < before {
+		sign = ""
+	}
+	return fmt.Sprintf("%s → %s (%s%s)", FormatPrice(before), FormatPrice(after), sign, FormatPrice(after-before))
+}
@@ -0,0 +1,123 @@
+package costs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// plansPayload is a response of the Scalingo API listing the plans of an addon
+// provider, the last plan has no price
+const plansPayload = `{
+  "plans": [
+    {
+      "id": "5a9d1e6e5aa1b4000b51e2a7",
+      "name": "postgresql-starter-512",
+      "display_name": "Starter 512M",
+      "price": 14.4,
+      "description": "[Starter 512M](https://scalingo.com/databases/postgresql)",
+      "position": 1,
+      "on_demand": false,
+      "disabled": false,
+      "sku": "classic:postgresql:starter:512"
+    },
+    {
+      "id": "5a9d1e6e5aa1b4000b51e2a8",
+      "name": "postgresql-business-1024",
+      "display_name": "Business 1G",
+      "price": 72.0,
+      "description": "[Business 1G](https://scalingo.com/databases/postgresql)",
+      "position": 2,
+      "on_demand": false,
+      "disabled": false,
+      "sku": "classic:postgresql:business:1024"
+    },
+    {
+      "id": "5a9d1e6e5aa1b4000b51e2a9",
+      "name": "postgresql-dedicated",
+      "display_name": "Dedicated",
+      "description": "Contact us",
+      "position": 3,
+      "on_demand": true,
+      "disabled": false
+    }
+  ]
+}`
+
+func TestPrices_Plan(t *testing.T) {
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(plansPayload))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c, err := scalingo.New(ctx, scalingo.ClientConfig{APIEndpoint: server.URL})
+	require.NoError(t, err)
+	prices := NewPricesFromSizes(c, nil)
+
+	tests := map[string]struct {
+		plan          string
+		expected      int
+		expectedError string
+	}{
+		"with a plan ID":       {plan: "5a9d1e6e5aa1b4000b51e2a7", expected: 1440},
+		"with a plan name":     {plan: "postgresql-business-1024", expected: 7200},
+		"without price":        {plan: "postgresql-dedicated", expectedError: "the price of the plan postgresql-dedicated of scalingo-postgres is unknown"},
+		"with an unknown plan": {plan: "postgresql-missing", expectedError: "unknown plan postgresql-missing"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			price, err := prices.Plan(ctx, "scalingo-postgres", test.plan)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, price)
+		})
+	}
+
+	// The plans are requested once, with the name of the provider corrected
+	assert.Equal(t, []string{"/v1/addon_providers/scalingo-postgresql/plans"}, paths)
+}
+
+func TestFormationCost(t *testing.T) {
+	prices := &Prices{containerSizes: map[string]int{"S": 720, "M": 1440}}
+
+	total, unknown := FormationCost(prices, []scalingo.ContainerType{
+		{Name: "web", Amount: 2, Size: "M"},
+		{Name: "worker", Amount: 1, Size: "S"},
+		{Name: "clock", Amount: 0, Size: "XS"},
+		{Name: "gpu", Amount: 1, Size: "2XL"},
+	})
+	assert.Equal(t, 3600, total)
+	assert.Equal(t, []string{"2XL"}, unknown)
+}
+
+func TestFormatPriceChange(t *testing.T) {
+	tests := map[string]struct {
+		before   int
+		after    int
+		expected string
+	}{
+		"without change":   {before: 1440, after: 1440, expected: "14.40 €"},
+		"with an increase": {before: 1440, after: 2880, expected: "14.40 € → 28.80 € (+14.40 €)"},
+		"with a decrease":  {before: 2880, after: 705, expected: "28.80 € → 7.05 € (-21.75 €)"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatPriceChange(test.before, test.after))
+		})
+	}
+}
@@ -17,6 +17,19 @@ import (
 // app flag, environment variable "SCALINGO_APP", current Git remote
 // returns an empty string if not found
 func CurrentApp(c *cli.Context) string {
+	appName := CurrentAppName(c)
+	if appName == "" {
+		fmt.Println("Unable to find the application name, please use --app flag.")
+		os.Exit(1)
+	}
+	debug.Println("[detect] App name is", appName)
+
+	return appName
+}
+
+// CurrentAppName returns the app name the same way CurrentApp does but
+// returns an empty string instead of exiting if the app name is not found
+func CurrentAppName(c *cli.Context) string {
 	var err error
 
 	appName := extractAppNameFromCommandLine(c)
@@ -33,12 +46,6 @@ func CurrentApp(c *cli.Context) string {
 		}
 	}
 
-	if appName == "" {
-		fmt.Println("Unable to find the application name, please use --app flag.")
-		os.Exit(1)
-	}
-	debug.Println("[detect] App name is", appName)
-
 	return appName
 }
 
@@ -11,7 +11,7 @@ import (
 )
 
 func Add(ctx context.Context, app string, domain string, cert string, key string) error {
-	certContent, keyContent, err := validateSSL(cert, key)
+	certContent, keyContent, err := validateSSL(domain, cert, key)
 	if err != nil {
 		return errgo.Mask(err)
 	}
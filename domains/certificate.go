@@ -0,0 +1,161 @@
+package domains
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+)
+
+// CertificateValidationOpts are the parameters of ValidateCertificate
+type CertificateValidationOpts struct {
+	// Domain which must be covered by the certificate, not checked if empty
+	Domain string
+	// Roots are the trusted certificate authorities, the system ones if nil
+	Roots *x509.CertPool
+	// Now is the date at which the certificate must be valid
+	Now time.Time
+}
+
+// ValidateCertificate checks locally that a PEM encoded certificate chain and
+// its private key can be installed on a domain:
+// - the private key matches the certificate
+// - the certificate is currently valid
+// - the certificate covers the domain
+// - the chain contains all the intermediate certificates up to a trusted authority
+//
+// A self-signed certificate is accepted, only a warning is displayed.
+func ValidateCertificate(certContent, keyContent []byte, opts CertificateValidationOpts) error {
+	certs, err := ParseCertificates(certContent)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	_, err = tls.X509KeyPair(certContent, keyContent)
+	if err != nil {
+		return errgo.Notef(err, "the private key does not match the certificate")
+	}
+
+	leaf := certs[0]
+	if opts.Now.Before(leaf.NotBefore) {
+		return errgo.Newf("the certificate is not valid before %s", leaf.NotBefore.Format(time.RFC3339))
+	}
+	if opts.Now.After(leaf.NotAfter) {
+		return errgo.Newf("the certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	if opts.Domain != "" {
+		err = leaf.VerifyHostname(opts.Domain)
+		if err != nil {
+			return errgo.Newf("the certificate does not cover %s, it is valid for: %s", opts.Domain, strings.Join(CertificateNames(leaf), ", "))
+		}
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   opts.Now,
+	})
+	if err != nil {
+		last := certs[len(certs)-1]
+		if _, ok := err.(x509.UnknownAuthorityError); !ok {
+			return errgo.Notef(err, "invalid certificate chain")
+		}
+		if isSelfSigned(last) {
+			io.Warningf("The certificate is signed by '%s' which is not a trusted authority, browsers will display a security warning.\n", last.Subject.CommonName)
+			return nil
+		}
+		return errgo.Newf(
+			"the certificate chain is incomplete, the certificate of '%s' is missing: append the intermediate certificates after the certificate of the domain in the certificate file",
+			last.Issuer.CommonName,
+		)
+	}
+	return nil
+}
+
+// ParseCertificates parses all the certificates of a PEM encoded chain
+func ParseCertificates(content []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	rest := bytes.TrimSpace(content)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to parse the certificate #%d of the chain", len(certs)+1)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errgo.New("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// CertificateNames returns the names covered by a certificate
+func CertificateNames(cert *x509.Certificate) []string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 && cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	return names
+}
+
+// CertificateIssuer returns a human readable name of the authority which
+// signed the certificate
+func CertificateIssuer(cert *x509.Certificate) string {
+	if cert.Issuer.CommonName == "" {
+		return cert.Issuer.String()
+	}
+	if len(cert.Issuer.Organization) > 0 && !strings.Contains(cert.Issuer.CommonName, cert.Issuer.Organization[0]) {
+		return fmt.Sprintf("%s (%s)", cert.Issuer.CommonName, cert.Issuer.Organization[0])
+	}
+	return cert.Issuer.CommonName
+}
+
+// FetchServedCertificate connects to the HTTPS endpoint of a domain and
+// returns the certificate served for it. The certificate is not verified: it
+// is only fetched to be inspected.
+func FetchServedCertificate(domain string, timeout time.Duration) (*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(domain, "443"), &tls.Config{
+		ServerName:         domain,
+		InsecureSkipVerify: true, // the certificate is only inspected, not trusted
+	})
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to connect to %s", domain)
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errgo.Newf("no certificate served by %s", domain)
+	}
+	return certs[0], nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
@@ -0,0 +1,132 @@
+package domains
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c testCertificate) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestValidateCertificate(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	notBefore, notAfter := now.AddDate(0, -1, 0), now.AddDate(0, 2, 0)
+
+	root := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test Root"},
+		NotBefore: notBefore, NotAfter: notAfter.AddDate(1, 0, 0),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil)
+	intermediate := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "Test Intermediate"},
+		NotBefore: notBefore, NotAfter: notAfter.AddDate(1, 0, 0),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, &root)
+	leaf := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "www.example.com"},
+		DNSNames:  []string{"www.example.com", "example.com"},
+		NotBefore: notBefore, NotAfter: notAfter,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &intermediate)
+	selfSigned := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(4), Subject: pkix.Name{CommonName: "www.example.com"},
+		DNSNames:  []string{"www.example.com"},
+		NotBefore: notBefore, NotAfter: notAfter,
+	}, nil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+	chain := append(append([]byte{}, leaf.pem...), intermediate.pem...)
+
+	tests := map[string]struct {
+		cert          []byte
+		key           []byte
+		domain        string
+		now           time.Time
+		expectedError string
+	}{
+		"a complete chain covering the domain is valid": {
+			cert: chain, key: leaf.keyPEM(t), domain: "www.example.com", now: now,
+		},
+		"a self-signed certificate is accepted": {
+			cert: selfSigned.pem, key: selfSigned.keyPEM(t), domain: "www.example.com", now: now,
+		},
+		"the content must contain a certificate": {
+			cert: leaf.keyPEM(t), key: leaf.keyPEM(t), now: now,
+			expectedError: "no PEM encoded certificate found",
+		},
+		"the key must match the certificate": {
+			cert: chain, key: selfSigned.keyPEM(t), domain: "www.example.com", now: now,
+			expectedError: "the private key does not match the certificate",
+		},
+		"an expired certificate is refused": {
+			cert: chain, key: leaf.keyPEM(t), domain: "www.example.com", now: notAfter.AddDate(0, 0, 1),
+			expectedError: "the certificate expired on",
+		},
+		"the certificate must cover the domain": {
+			cert: chain, key: leaf.keyPEM(t), domain: "api.example.com", now: now,
+			expectedError: "the certificate does not cover api.example.com, it is valid for: www.example.com, example.com",
+		},
+		"the intermediate certificate must be in the chain": {
+			cert: leaf.pem, key: leaf.keyPEM(t), domain: "www.example.com", now: now,
+			expectedError: "the certificate chain is incomplete, the certificate of 'Test Intermediate' is missing",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			err := ValidateCertificate(test.cert, test.key, CertificateValidationOpts{
+				Domain: test.domain,
+				Roots:  roots,
+				Now:    test.now,
+			})
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
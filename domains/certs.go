@@ -0,0 +1,185 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	certsReportConcurrency   = 8
+	servedCertificateTimeout = 5 * time.Second
+)
+
+// CertsReportOpts are the parameters of CertsReport
+type CertsReportOpts struct {
+	// App to inspect, ignored if AllApps is set
+	App string
+	// AllApps inspects all the applications of the user
+	AllApps bool
+	// ExpiringWithin is the period during which an expiring certificate is
+	// reported as an error
+	ExpiringWithin time.Duration
+}
+
+// certificateReport describes a manually managed certificate of a domain
+type certificateReport struct {
+	App      string
+	Domain   string
+	Issuer   string
+	Names    []string
+	Validity time.Time
+	Error    error
+}
+
+// CertsReport lists the certificates manually installed on the domains of the
+// given applications, the ones managed by Let's Encrypt are renewed
+// automatically and are ignored. An error is returned if any certificate
+// expires in less than opts.ExpiringWithin.
+func CertsReport(ctx context.Context, opts CertsReportOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	apps := []string{opts.App}
+	if opts.AllApps {
+		userApps, err := c.AppsList(ctx)
+		if err != nil {
+			return errgo.Notef(err, "fail to list the applications")
+		}
+		apps = make([]string, 0, len(userApps))
+		for _, app := range userApps {
+			apps = append(apps, app.Name)
+		}
+	}
+
+	var (
+		lock      sync.Mutex
+		wg        sync.WaitGroup
+		reports   []certificateReport
+		appErrors []string
+	)
+	semaphore := make(chan struct{}, certsReportConcurrency)
+	for _, app := range apps {
+		wg.Add(1)
+		go func(app string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			appReports, err := appCertificates(ctx, c, app)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				appErrors = append(appErrors, fmt.Sprintf("%s: %v", app, err))
+				return
+			}
+			reports = append(reports, appReports...)
+		}(app)
+	}
+	wg.Wait()
+
+	for _, appError := range appErrors {
+		io.Warning("Fail to list the domains of", appError)
+	}
+	if len(reports) == 0 {
+		io.Status("No manually installed certificate found.")
+		return nil
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Validity.Before(reports[j].Validity)
+	})
+
+	now := time.Now()
+	limit := now.Add(opts.ExpiringWithin)
+	expiring := 0
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"App", "Domain", "Issuer", "Names", "Expires", "Status"})
+	for _, report := range reports {
+		issuer, names := report.Issuer, strings.Join(report.Names, ", ")
+		if report.Error != nil {
+			issuer, names = "-", "-"
+		}
+		if issuer == "" {
+			issuer = "-"
+		}
+
+		status := io.Green("Valid")
+		if report.Validity.Before(now) {
+			status = io.BoldRed("Expired")
+			expiring++
+		} else if report.Validity.Before(limit) {
+			status = io.BoldRed(fmt.Sprintf("Expires in %d days", int(report.Validity.Sub(now).Hours()/24)))
+			expiring++
+		}
+
+		t.Append([]string{report.App, report.Domain, issuer, names, report.Validity.Format("2006-01-02"), status})
+	}
+	t.Render()
+
+	if expiring > 0 {
+		return errgo.Newf("%d certificate(s) expired or expiring within %v", expiring, formatDays(opts.ExpiringWithin))
+	}
+	return nil
+}
+
+// appCertificates inspects the manually installed certificates of an
+// application. The API only returns the validity of the certificates, the
+// issuer and the names are read from the certificate served by the domain.
+func appCertificates(ctx context.Context, c *scalingo.Client, app string) ([]certificateReport, error) {
+	domains, err := c.DomainsList(ctx, app)
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+
+	reports := []certificateReport{}
+	for _, domain := range domains {
+		if !domain.SSL || domain.LetsEncrypt {
+			continue
+		}
+		report := certificateReport{
+			App:      app,
+			Domain:   domain.Name,
+			Validity: domain.Validity,
+		}
+
+		if strings.HasPrefix(domain.Name, "*.") {
+			// There is no host to connect to for a wildcard domain
+			report.Names = []string{domain.Name}
+			reports = append(reports, report)
+			continue
+		}
+
+		cert, err := FetchServedCertificate(domain.Name, servedCertificateTimeout)
+		if err != nil {
+			config.C.Logger.Printf("Fail to fetch the certificate of %s: %v\n", domain.Name, err)
+			report.Error = err
+		} else {
+			report.Issuer = CertificateIssuer(cert)
+			report.Names = CertificateNames(cert)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func formatDays(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d days", d/(24*time.Hour))
+	}
+	return d.String()
+}
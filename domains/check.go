@@ -0,0 +1,295 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// Resolver is the subset of *net.Resolver used to diagnose the DNS
+// configuration of the domains
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver returns a resolver querying the DNS server at the given
+// address (host:port). The resolver of the system is used if the address is
+// empty.
+func NewResolver(address string) Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 5 * time.Second}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// DomainDiagnostic is the result of the DNS check of a domain
+type DomainDiagnostic struct {
+	Domain string
+	// Target is the hostname of the application the domain should point to
+	Target string
+	CNAME  string
+	IPs    []string
+	// TargetIPs are the addresses of the application
+	TargetIPs []string
+	// PointsToApp is true if the domain resolves to the application
+	PointsToApp bool
+	// ACMEChecked is true if a DNS challenge is required to get the Let's
+	// Encrypt certificate, ACMEValid gives its result
+	ACMEChecked bool
+	ACMEValid   bool
+	// Problems and Instructions explain what is wrong and which records to
+	// create
+	Problems     []string
+	Instructions []string
+}
+
+func (d DomainDiagnostic) OK() bool {
+	return len(d.Problems) == 0
+}
+
+// Check diagnoses the DNS configuration of the domains of the application. If
+// domainName is not empty, only this domain is checked.
+func Check(ctx context.Context, app, domainName, resolverAddress string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	scalingoApp, err := c.AppsShow(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the application")
+	}
+	target, err := AppTarget(scalingoApp)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	domains, err := c.DomainsList(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the domains")
+	}
+	if domainName != "" {
+		d, err := domainByName(domains, app, domainName)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		domains = []scalingo.Domain{d}
+	}
+	if len(domains) == 0 {
+		io.Status("The application has no custom domain.")
+		return nil
+	}
+
+	resolver := NewResolver(resolverAddress)
+	failures := 0
+	for i, domain := range domains {
+		if i > 0 {
+			fmt.Println()
+		}
+		diagnostic := CheckDomain(ctx, resolver, domain, target)
+		displayDiagnostic(diagnostic)
+		if !diagnostic.OK() {
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return errgo.Newf("%d domain(s) are not correctly configured", failures)
+	}
+	return nil
+}
+
+// AppTarget returns the hostname the custom domains of the application
+// should point to, e.g. my-app.osc-fr1.scalingo.io
+func AppTarget(app *scalingo.App) (string, error) {
+	baseURL, err := url.Parse(app.BaseURL)
+	if err != nil || baseURL.Hostname() == "" {
+		return "", errgo.Newf("invalid base URL of the application: '%s'", app.BaseURL)
+	}
+	return baseURL.Hostname(), nil
+}
+
+// CheckDomain resolves the records of the domain and compares them to the
+// target of the application
+func CheckDomain(ctx context.Context, resolver Resolver, domain scalingo.Domain, target string) DomainDiagnostic {
+	d := DomainDiagnostic{
+		Domain: domain.Name,
+		Target: target,
+	}
+
+	targetIPs, err := lookupIPs(ctx, resolver, target)
+	if err != nil {
+		d.Problems = append(d.Problems, fmt.Sprintf("Fail to resolve the application hostname %s: %v", target, err))
+	}
+	d.TargetIPs = targetIPs
+
+	cname, err := resolver.LookupCNAME(ctx, domain.Name)
+	if err == nil && normalizeHost(cname) != normalizeHost(domain.Name) {
+		d.CNAME = normalizeHost(cname)
+	}
+	ips, err := lookupIPs(ctx, resolver, domain.Name)
+	if err != nil {
+		d.Problems = append(d.Problems, fmt.Sprintf("%s does not resolve: %v", domain.Name, err))
+	}
+	d.IPs = ips
+
+	switch {
+	case d.CNAME == normalizeHost(target):
+		d.PointsToApp = true
+	case len(ips) > 0 && len(targetIPs) > 0 && includesAll(targetIPs, ips):
+		d.PointsToApp = true
+	case d.CNAME != "":
+		d.Problems = append(d.Problems, fmt.Sprintf("%s is an alias of %s instead of %s", domain.Name, d.CNAME, target))
+	case len(ips) > 0:
+		d.Problems = append(d.Problems, fmt.Sprintf("%s resolves to %s which are not the addresses of %s (%s)",
+			domain.Name, strings.Join(ips, ", "), target, strings.Join(targetIPs, ", ")))
+	}
+
+	if !d.PointsToApp {
+		if isApexDomain(domain.Name) {
+			d.Instructions = append(d.Instructions, fmt.Sprintf("Create an ALIAS (or ANAME) record if your DNS provider supports it: %s. ALIAS %s.", domain.Name, target))
+			for _, ip := range targetIPs {
+				recordType := "A"
+				if strings.Contains(ip, ":") {
+					recordType = "AAAA"
+				}
+				d.Instructions = append(d.Instructions, fmt.Sprintf("Otherwise create the record: %s. %s %s", domain.Name, recordType, ip))
+			}
+		} else {
+			d.Instructions = append(d.Instructions, fmt.Sprintf("Create a CNAME record: %s. CNAME %s.", domain.Name, target))
+		}
+		if d.CNAME != "" || len(ips) > 0 {
+			d.Instructions = append(d.Instructions, fmt.Sprintf("Remove the existing records of %s which do not point to %s", domain.Name, target))
+		}
+	}
+
+	if domain.LetsEncrypt && domain.LetsEncryptStatus == scalingo.LetsEncryptStatusDNSRequired {
+		d.ACMEChecked = true
+		d.ACMEValid = checkACMEChallenge(ctx, resolver, domain)
+		if !d.ACMEValid {
+			d.Problems = append(d.Problems, "The DNS challenge required to generate the Let's Encrypt certificate is not configured")
+			d.Instructions = append(d.Instructions, fmt.Sprintf("Create a TXT record: %s. TXT \"%s\"", normalizeHost(domain.AcmeDNSFqdn), domain.AcmeDNSValue))
+		}
+	}
+	if domain.LetsEncrypt && domain.LetsEncryptStatus == scalingo.LetsEncryptStatusPendingDNS && d.PointsToApp {
+		d.Instructions = append(d.Instructions, "The DNS records are correct, the Let's Encrypt certificate will be generated once the DNS propagation is over")
+	}
+
+	return d
+}
+
+// checkACMEChallenge returns true if the value of the DNS challenge is
+// published on the challenge FQDN, either directly or through a CNAME
+func checkACMEChallenge(ctx context.Context, resolver Resolver, domain scalingo.Domain) bool {
+	if domain.AcmeDNSFqdn == "" || domain.AcmeDNSValue == "" {
+		return false
+	}
+	records, err := resolver.LookupTXT(ctx, domain.AcmeDNSFqdn)
+	if err != nil {
+		return false
+	}
+	for _, record := range records {
+		if strings.TrimSpace(record) == domain.AcmeDNSValue {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupIPs(ctx context.Context, resolver Resolver, host string) ([]string, error) {
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP.String())
+	}
+	sort.Strings(ips)
+	return ips, nil
+}
+
+// includesAll returns true if all the values are in the reference
+func includesAll(reference, values []string) bool {
+	for _, v := range values {
+		found := false
+		for _, r := range reference {
+			if r == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// isApexDomain returns true if the domain is at the root of its zone, where a
+// CNAME record is forbidden: the domain registered under a public suffix, like
+// example.com or example.co.uk.
+func isApexDomain(domain string) bool {
+	domain = normalizeHost(domain)
+	registered, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return false
+	}
+	return registered == domain
+}
+
+func displayDiagnostic(d DomainDiagnostic) {
+	if d.OK() {
+		io.Statusf("%s %s\n", d.Domain, io.Green("OK"))
+	} else {
+		io.Statusf("%s %s\n", d.Domain, io.BoldRed("ERROR"))
+	}
+
+	if d.CNAME != "" {
+		io.Infof("CNAME: %s\n", d.CNAME)
+	}
+	if len(d.IPs) > 0 {
+		io.Infof("Addresses: %s\n", strings.Join(d.IPs, ", "))
+	}
+	io.Infof("Expected target: %s (%s)\n", d.Target, strings.Join(d.TargetIPs, ", "))
+	if d.ACMEChecked {
+		status := io.Green("OK")
+		if !d.ACMEValid {
+			status = io.BoldRed("missing")
+		}
+		io.Infof("Let's Encrypt DNS challenge: %s\n", status)
+	}
+
+	for _, problem := range d.Problems {
+		io.Infof("%s %s\n", io.BoldRed("✘"), problem)
+	}
+	for _, instruction := range d.Instructions {
+		io.Info("→ " + instruction)
+	}
+}
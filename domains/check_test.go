@@ -0,0 +1,159 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// stubResolver is a DNS stub answering from static records
+type stubResolver struct {
+	cnames map[string]string
+	ips    map[string][]string
+	txts   map[string][]string
+}
+
+func (r stubResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	if cname, ok := r.cnames[host]; ok {
+		return cname + ".", nil
+	}
+	return host + ".", nil
+}
+
+func (r stubResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	if cname, ok := r.cnames[host]; ok {
+		host = cname
+	}
+	ips, ok := r.ips[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	res := []net.IPAddr{}
+	for _, ip := range ips {
+		res = append(res, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return res, nil
+}
+
+func (r stubResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	txts, ok := r.txts[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return txts, nil
+}
+
+func TestCheckDomain(t *testing.T) {
+	target := "my-app.osc-fr1.scalingo.io"
+	targetIPs := []string{"1.2.3.4", "1.2.3.5"}
+
+	tests := map[string]struct {
+		domain           scalingo.Domain
+		resolver         stubResolver
+		expectOK         bool
+		expectedCommands []string
+	}{
+		"a subdomain with a CNAME to the app is valid": {
+			domain: scalingo.Domain{Name: "www.example.com"},
+			resolver: stubResolver{
+				cnames: map[string]string{"www.example.com": target},
+			},
+			expectOK: true,
+		},
+		"an apex domain with the A records of the app is valid": {
+			domain: scalingo.Domain{Name: "example.com"},
+			resolver: stubResolver{
+				ips: map[string][]string{"example.com": {"1.2.3.4"}},
+			},
+			expectOK: true,
+		},
+		"a subdomain without record should be told to create a CNAME": {
+			domain:           scalingo.Domain{Name: "www.example.com"},
+			expectOK:         false,
+			expectedCommands: []string{"Create a CNAME record: www.example.com. CNAME my-app.osc-fr1.scalingo.io."},
+		},
+		"a subdomain pointing elsewhere should be told to replace the records": {
+			domain: scalingo.Domain{Name: "www.example.com"},
+			resolver: stubResolver{
+				ips: map[string][]string{"www.example.com": {"9.9.9.9"}},
+			},
+			expectOK: false,
+			expectedCommands: []string{
+				"Create a CNAME record: www.example.com. CNAME my-app.osc-fr1.scalingo.io.",
+				"Remove the existing records of www.example.com which do not point to my-app.osc-fr1.scalingo.io",
+			},
+		},
+		"an apex domain without record should be told to create A records": {
+			domain:   scalingo.Domain{Name: "example.com"},
+			expectOK: false,
+			expectedCommands: []string{
+				"Create an ALIAS (or ANAME) record if your DNS provider supports it: example.com. ALIAS my-app.osc-fr1.scalingo.io.",
+				"Otherwise create the record: example.com. A 1.2.3.4",
+				"Otherwise create the record: example.com. A 1.2.3.5",
+			},
+		},
+		"a missing ACME DNS challenge should be reported": {
+			domain: scalingo.Domain{
+				Name: "*.example.com", LetsEncrypt: true, LetsEncryptStatus: scalingo.LetsEncryptStatusDNSRequired,
+				AcmeDNSFqdn: "_acme-challenge.example.com", AcmeDNSValue: "challenge-value",
+			},
+			resolver: stubResolver{
+				cnames: map[string]string{"*.example.com": target},
+			},
+			expectOK:         false,
+			expectedCommands: []string{`Create a TXT record: _acme-challenge.example.com. TXT "challenge-value"`},
+		},
+		"a published ACME DNS challenge is valid": {
+			domain: scalingo.Domain{
+				Name: "*.example.com", LetsEncrypt: true, LetsEncryptStatus: scalingo.LetsEncryptStatusDNSRequired,
+				AcmeDNSFqdn: "_acme-challenge.example.com", AcmeDNSValue: "challenge-value",
+			},
+			resolver: stubResolver{
+				cnames: map[string]string{"*.example.com": target},
+				txts:   map[string][]string{"_acme-challenge.example.com": {"challenge-value"}},
+			},
+			expectOK: true,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			if test.resolver.ips == nil {
+				test.resolver.ips = map[string][]string{}
+			}
+			test.resolver.ips[target] = targetIPs
+
+			diagnostic := CheckDomain(context.Background(), test.resolver, test.domain, target)
+
+			assert.Equal(t, test.expectOK, diagnostic.OK(), "problems: %v", diagnostic.Problems)
+			if test.expectedCommands != nil {
+				assert.Equal(t, test.expectedCommands, diagnostic.Instructions)
+			}
+		})
+	}
+}
+
+func TestIsApexDomain(t *testing.T) {
+	tests := map[string]struct {
+		domain   string
+		expected bool
+	}{
+		"with a domain":                         {domain: "example.com", expected: true},
+		"with a subdomain":                      {domain: "www.example.com", expected: false},
+		"with a domain of a 2 labels suffix":    {domain: "example.co.uk", expected: true},
+		"with a subdomain of a 2 labels suffix": {domain: "www.example.co.uk", expected: false},
+		"with a trailing dot":                   {domain: "Example.com.", expected: true},
+		"with a public suffix":                  {domain: "co.uk", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isApexDomain(test.domain))
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package domains
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatYAML = "yaml"
+)
+
+// ImportOpts are the parameters of Import
+type ImportOpts struct {
+	// File listing the domains of the application
+	File string
+	// Format of the file, guessed from its extension if empty
+	Format string
+	// Prune removes the domains of the application which are not in the file
+	Prune bool
+	// DryRun only displays the changes which would be applied
+	DryRun bool
+	// Yes removes the domains pruned without asking for a confirmation
+	Yes bool
+}
+
+// ImportEntry is a domain listed in an import file. The certificate and key
+// paths are relative to the directory of the file.
+type ImportEntry struct {
+	Name      string `yaml:"name"`
+	Cert      string `yaml:"cert"`
+	Key       string `yaml:"key"`
+	Canonical bool   `yaml:"canonical"`
+
+	certContent  string
+	keyContent   string
+	certValidity time.Time
+}
+
+func (e ImportEntry) hasCertificate() bool {
+	return e.certContent != ""
+}
+
+type importFile struct {
+	Domains []ImportEntry `yaml:"domains"`
+}
+
+// importPlan is the list of changes to apply on the domains of an application
+type importPlan struct {
+	Add            []ImportEntry
+	SetCertificate []ImportEntry
+	Remove         []scalingo.Domain
+	// Canonical is the domain to set as canonical, empty if it is unchanged
+	Canonical         string
+	PreviousCanonical string
+}
+
+func (p importPlan) IsEmpty() bool {
+	return len(p.Add) == 0 && len(p.SetCertificate) == 0 && len(p.Remove) == 0 && p.Canonical == ""
+}
+
+// Import synchronizes the domains of an application with the content of a
+// CSV or YAML file: missing domains are added, certificates are installed
+// and the canonical domain is set. With opts.Prune, the domains which are not
+// in the file are removed.
+func Import(ctx context.Context, app string, opts ImportOpts) error {
+	entries, err := ReadImportFile(opts.File, opts.Format)
+	if err != nil {
+		return errgo.Notef(err, "fail to read the domains file")
+	}
+	// An empty or truncated file must not wipe all the domains
+	if opts.Prune && len(entries) == 0 {
+		return errgo.Newf("%s lists no domain, refusing to remove all the domains of the application with --prune", opts.File)
+	}
+	err = loadImportCertificates(entries, filepath.Dir(opts.File))
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	existing, err := c.DomainsList(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the domains of the application")
+	}
+
+	plan := planImport(existing, entries, opts.Prune)
+	if plan.IsEmpty() {
+		io.Status("The domains of", app, "are up to date.")
+		return nil
+	}
+
+	displayImportPlan(plan)
+	if opts.DryRun {
+		return nil
+	}
+	if len(plan.Remove) > 0 && !opts.Yes {
+		err := confirmImportRemovals(plan)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+	}
+	fmt.Println()
+
+	for _, entry := range plan.Add {
+		_, err := c.DomainsAdd(ctx, app, scalingo.Domain{
+			Name:    entry.Name,
+			TLSCert: entry.certContent,
+			TLSKey:  entry.keyContent,
+		})
+		if err != nil {
+			return errgo.Notef(err, "fail to add the domain %s", entry.Name)
+		}
+		io.Status("Domain", entry.Name, "has been added")
+	}
+
+	if len(plan.SetCertificate) > 0 || plan.Canonical != "" {
+		// The IDs of the added domains are needed
+		existing, err = c.DomainsList(ctx, app)
+		if err != nil {
+			return errgo.Notef(err, "fail to list the domains of the application")
+		}
+	}
+	ids := map[string]string{}
+	for _, domain := range existing {
+		ids[domain.Name] = domain.ID
+	}
+
+	for _, entry := range plan.SetCertificate {
+		_, err := c.DomainSetCertificate(ctx, app, ids[entry.Name], entry.certContent, entry.keyContent)
+		if err != nil {
+			return errgo.Notef(err, "fail to set the certificate of %s", entry.Name)
+		}
+		io.Status("The certificate of", entry.Name, "has been installed")
+	}
+
+	if plan.Canonical != "" {
+		_, err := c.DomainSetCanonical(ctx, app, ids[plan.Canonical])
+		if err != nil {
+			return errgo.Notef(err, "fail to set the canonical domain")
+		}
+		io.Statusf("Canonical domain set to %s\n", plan.Canonical)
+	}
+
+	for _, domain := range plan.Remove {
+		err := c.DomainsRemove(ctx, app, domain.ID)
+		if err != nil {
+			return errgo.Notef(err, "fail to remove the domain %s", domain.Name)
+		}
+		io.Status("The domain", domain.Name, "has been deleted")
+	}
+	return nil
+}
+
+// ReadImportFile parses the list of domains of a CSV or YAML file. format is
+// guessed from the file extension if empty.
+//
+// The CSV file has the columns: domain,cert,key,canonical. The header line is
+// optional and the last columns can be omitted.
+//
+// The YAML file has the structure:
+//
+//	domains:
+//	- name: www.example.com
+//	  cert: www.example.com.crt
+//	  key: www.example.com.key
+//	  canonical: true
+func ReadImportFile(path, format string) ([]ImportEntry, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = ImportFormatCSV
+		case ".yml", ".yaml":
+			format = ImportFormatYAML
+		default:
+			return nil, errgo.Newf("unknown format of %s, use the --format flag", path)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+
+	var entries []ImportEntry
+	switch format {
+	case ImportFormatCSV:
+		entries, err = parseImportCSV(string(content))
+	case "yml", ImportFormatYAML:
+		entries, err = parseImportYAML(content)
+	default:
+		return nil, errgo.Newf("unknown format '%s', must be %s or %s", format, ImportFormatCSV, ImportFormatYAML)
+	}
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+	return entries, validateImportEntries(entries)
+}
+
+func parseImportCSV(content string) ([]ImportEntry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errgo.Notef(err, "invalid CSV")
+	}
+
+	entries := []ImportEntry{}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "domain") {
+			continue
+		}
+		if len(record) > 4 {
+			return nil, errgo.Newf("line %d: too many columns, expected domain,cert,key,canonical", i+1)
+		}
+		for len(record) < 4 {
+			record = append(record, "")
+		}
+		entry := ImportEntry{
+			Name: strings.TrimSpace(record[0]),
+			Cert: strings.TrimSpace(record[1]),
+			Key:  strings.TrimSpace(record[2]),
+		}
+		if canonical := strings.TrimSpace(record[3]); canonical != "" {
+			entry.Canonical, err = strconv.ParseBool(canonical)
+			if err != nil {
+				return nil, errgo.Newf("line %d: invalid canonical value '%s', must be true or false", i+1, canonical)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseImportYAML(content []byte) ([]ImportEntry, error) {
+	var file importFile
+	err := yaml.Unmarshal(content, &file)
+	if err != nil {
+		return nil, errgo.Notef(err, "invalid YAML")
+	}
+	return file.Domains, nil
+}
+
+func validateImportEntries(entries []ImportEntry) error {
+	seen := map[string]bool{}
+	canonical := ""
+	for _, entry := range entries {
+		if entry.Name == "" {
+			return errgo.New("a domain has no name")
+		}
+		if seen[entry.Name] {
+			return errgo.Newf("the domain %s is listed twice", entry.Name)
+		}
+		seen[entry.Name] = true
+
+		if (entry.Cert == "") != (entry.Key == "") {
+			return errgo.Newf("the domain %s must have both a certificate and a key", entry.Name)
+		}
+		if entry.Canonical {
+			if canonical != "" {
+				return errgo.Newf("only one canonical domain can be set, both %s and %s are", canonical, entry.Name)
+			}
+			canonical = entry.Name
+		}
+	}
+	return nil
+}
+
+// loadImportCertificates reads and validates the certificates of the entries
+func loadImportCertificates(entries []ImportEntry, dir string) error {
+	for i, entry := range entries {
+		if entry.Cert == "" {
+			continue
+		}
+		certPath, keyPath := entry.Cert, entry.Key
+		if !filepath.IsAbs(certPath) {
+			certPath = filepath.Join(dir, certPath)
+		}
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(dir, keyPath)
+		}
+
+		certContent, keyContent, err := validateSSL(entry.Name, certPath, keyPath)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		certs, err := ParseCertificates([]byte(certContent))
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		entries[i].certContent = certContent
+		entries[i].keyContent = keyContent
+		entries[i].certValidity = certs[0].NotAfter
+	}
+	return nil
+}
+
+// planImport computes the changes needed to go from the existing domains to
+// the ones of the entries. A certificate is considered already installed if
+// the validity of the installed one is the same.
+func planImport(existing []scalingo.Domain, entries []ImportEntry, prune bool) importPlan {
+	plan := importPlan{}
+	existingDomains := map[string]scalingo.Domain{}
+	for _, domain := range existing {
+		existingDomains[domain.Name] = domain
+		if domain.Canonical {
+			plan.PreviousCanonical = domain.Name
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, entry := range entries {
+		listed[entry.Name] = true
+		if entry.Canonical && plan.PreviousCanonical != entry.Name {
+			plan.Canonical = entry.Name
+		}
+
+		domain, ok := existingDomains[entry.Name]
+		if !ok {
+			plan.Add = append(plan.Add, entry)
+			continue
+		}
+		if !entry.hasCertificate() {
+			continue
+		}
+		if domain.SSL && !domain.LetsEncrypt && domain.Validity.Equal(entry.certValidity) {
+			continue
+		}
+		plan.SetCertificate = append(plan.SetCertificate, entry)
+	}
+
+	if prune {
+		for _, domain := range existing {
+			if !listed[domain.Name] {
+				plan.Remove = append(plan.Remove, domain)
+			}
+		}
+	}
+	return plan
+}
+
+// confirmImportRemovals asks to type the number of domains to remove, the
+// removal of the canonical domain is highlighted
+func confirmImportRemovals(plan importPlan) error {
+	fmt.Println()
+	for _, domain := range plan.Remove {
+		if domain.Canonical {
+			io.Warningf("%s is the canonical domain of the application\n", domain.Name)
+		}
+	}
+	fmt.Printf("/!\\ You're going to remove %d domains, this operation is irreversible.\nTo confirm type the number of domains to remove: ", len(plan.Remove))
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer != strconv.Itoa(len(plan.Remove)) {
+		return errgo.Newf("'%s' is not '%d', aborting…", answer, len(plan.Remove))
+	}
+	return nil
+}
+
+func displayImportPlan(plan importPlan) {
+	for _, entry := range plan.Add {
+		line := "+ " + entry.Name
+		if entry.hasCertificate() {
+			line += fmt.Sprintf(" (certificate valid until %s)", entry.certValidity.Format("2006-01-02"))
+		}
+		fmt.Println(io.Green(line))
+	}
+	for _, entry := range plan.SetCertificate {
+		fmt.Println(io.Yellow(fmt.Sprintf("~ %s: install the certificate valid until %s", entry.Name, entry.certValidity.Format("2006-01-02"))))
+	}
+	if plan.Canonical != "" {
+		line := "~ canonical domain: " + plan.Canonical
+		if plan.PreviousCanonical != "" {
+			line += " (was " + plan.PreviousCanonical + ")"
+		}
+		fmt.Println(io.Yellow(line))
+	}
+	for _, domain := range plan.Remove {
+		fmt.Println(io.BoldRed("- " + domain.Name))
+	}
+}
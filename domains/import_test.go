@@ -0,0 +1,159 @@
+package domains
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	tests := map[string]struct {
+		content         string
+		expectedEntries []ImportEntry
+		expectedError   string
+	}{
+		"a file with a header and all the columns": {
+			content: "domain,cert,key,canonical\nwww.example.com,www.crt,www.key,true\nexample.com,,,\n",
+			expectedEntries: []ImportEntry{
+				{Name: "www.example.com", Cert: "www.crt", Key: "www.key", Canonical: true},
+				{Name: "example.com"},
+			},
+		},
+		"a file without header and with omitted columns": {
+			content: "www.example.com\n# a comment\napi.example.com, api.crt, api.key\n",
+			expectedEntries: []ImportEntry{
+				{Name: "www.example.com"},
+				{Name: "api.example.com", Cert: "api.crt", Key: "api.key"},
+			},
+		},
+		"an invalid canonical value": {
+			content:       "www.example.com,,,yes please\n",
+			expectedError: "line 1: invalid canonical value 'yes please'",
+		},
+		"too many columns": {
+			content:       "www.example.com,,,true,extra\n",
+			expectedError: "line 1: too many columns",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			entries, err := parseImportCSV(test.content)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedEntries, entries)
+		})
+	}
+}
+
+func TestValidateImportEntries(t *testing.T) {
+	tests := map[string]struct {
+		entries       []ImportEntry
+		expectedError string
+	}{
+		"valid entries": {
+			entries: []ImportEntry{{Name: "www.example.com", Cert: "a.crt", Key: "a.key", Canonical: true}, {Name: "example.com"}},
+		},
+		"a duplicated domain": {
+			entries:       []ImportEntry{{Name: "www.example.com"}, {Name: "www.example.com"}},
+			expectedError: "the domain www.example.com is listed twice",
+		},
+		"a certificate without key": {
+			entries:       []ImportEntry{{Name: "www.example.com", Cert: "a.crt"}},
+			expectedError: "the domain www.example.com must have both a certificate and a key",
+		},
+		"two canonical domains": {
+			entries:       []ImportEntry{{Name: "www.example.com", Canonical: true}, {Name: "example.com", Canonical: true}},
+			expectedError: "only one canonical domain can be set",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			err := validateImportEntries(test.entries)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	validity := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := []scalingo.Domain{
+		{ID: "1", Name: "www.example.com", SSL: true, Validity: validity, Canonical: true},
+		{ID: "2", Name: "api.example.com"},
+		{ID: "3", Name: "old.example.com"},
+	}
+	withCert := func(entry ImportEntry, validity time.Time) ImportEntry {
+		entry.certContent, entry.keyContent, entry.certValidity = "cert", "key", validity
+		return entry
+	}
+
+	tests := map[string]struct {
+		entries      []ImportEntry
+		prune        bool
+		expectedPlan importPlan
+	}{
+		"nothing changes when all the domains exist": {
+			entries: []ImportEntry{
+				withCert(ImportEntry{Name: "www.example.com", Canonical: true}, validity),
+				{Name: "api.example.com"},
+			},
+			expectedPlan: importPlan{PreviousCanonical: "www.example.com"},
+		},
+		"missing domains are added and new certificates are installed": {
+			entries: []ImportEntry{
+				withCert(ImportEntry{Name: "www.example.com"}, validity.AddDate(1, 0, 0)),
+				withCert(ImportEntry{Name: "api.example.com"}, validity),
+				{Name: "new.example.com", Canonical: true},
+			},
+			expectedPlan: importPlan{
+				Add: []ImportEntry{{Name: "new.example.com", Canonical: true}},
+				SetCertificate: []ImportEntry{
+					withCert(ImportEntry{Name: "www.example.com"}, validity.AddDate(1, 0, 0)),
+					withCert(ImportEntry{Name: "api.example.com"}, validity),
+				},
+				Canonical:         "new.example.com",
+				PreviousCanonical: "www.example.com",
+			},
+		},
+		"unlisted domains are removed with prune": {
+			entries: []ImportEntry{{Name: "www.example.com"}},
+			prune:   true,
+			expectedPlan: importPlan{
+				Remove:            []scalingo.Domain{existing[1], existing[2]},
+				PreviousCanonical: "www.example.com",
+			},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			plan := planImport(existing, test.entries, test.prune)
+			assert.Equal(t, test.expectedPlan, plan)
+		})
+	}
+}
+
+func TestImport_PruneWithoutEntries(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "domains.csv")
+	require.NoError(t, os.WriteFile(file, []byte("domain,cert,key,canonical\n"), 0644))
+
+	err := Import(context.Background(), "my-app", ImportOpts{File: file, Prune: true, Yes: true})
+	assert.ErrorContains(t, err, "lists no domain, refusing to remove all the domains")
+}
@@ -34,7 +34,11 @@ func findDomain(ctx context.Context, client *scalingo.Client, app string, domain
 	if err != nil {
 		return scalingo.Domain{}, errgo.Mask(err)
 	}
+	return domainByName(domains, app, domain)
+}
 
+// domainByName returns the domain of the list of domains of the application
+func domainByName(domains []scalingo.Domain, app string, domain string) (scalingo.Domain, error) {
 	for _, d := range domains {
 		if d.Name == domain {
 			return d, nil
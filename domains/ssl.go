@@ -3,6 +3,7 @@ package domains
 import (
 	"context"
 	"os"
+	"time"
 
 	"gopkg.in/errgo.v1"
 
@@ -40,7 +41,7 @@ func EnableSSL(ctx context.Context, app, domain, certPath, keyPath string) error
 		return errgo.Notef(err, "fail to find the matching domain to enable SSL")
 	}
 
-	certContent, keyContent, err := validateSSL(certPath, keyPath)
+	certContent, keyContent, err := validateSSL(d.Name, certPath, keyPath)
 	if err != nil {
 		return errgo.Notef(err, "fail to validate the given certificate and key")
 	}
@@ -54,7 +55,9 @@ func EnableSSL(ctx context.Context, app, domain, certPath, keyPath string) error
 	return nil
 }
 
-func validateSSL(cert, key string) (string, string, error) {
+// validateSSL reads the certificate and key files and checks that they can be
+// installed on the domain
+func validateSSL(domain, cert, key string) (string, string, error) {
 	if cert == "" && key == "" {
 		return "", "", nil
 	}
@@ -75,5 +78,13 @@ func validateSSL(cert, key string) (string, string, error) {
 	if err != nil {
 		return "", "", errgo.Notef(err, "fail to read the private key")
 	}
+
+	err = ValidateCertificate(certContent, keyContent, CertificateValidationOpts{
+		Domain: domain,
+		Now:    time.Now(),
+	})
+	if err != nil {
+		return "", "", errgo.Notef(err, "invalid certificate for %s", domain)
+	}
 	return string(certContent), string(keyContent), nil
 }
@@ -0,0 +1,172 @@
+package events
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stdio "io"
+	"os"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+const (
+	// allPerPage is the size of the pages requested when traversing all the
+	// pages of a timeline
+	allPerPage = 100
+	// maxAllPages is a safety net against an endless traversal
+	maxAllPages = 1000
+)
+
+// PageFetcher returns a page of events, most recent first, e.g. from the
+// EventsList or UserEventsList methods of the Scalingo client
+type PageFetcher func(ctx context.Context, opts scalingo.PaginationOpts) (scalingo.Events, scalingo.PaginationMeta, error)
+
+type TimelineOpts struct {
+	Pagination scalingo.PaginationOpts
+	Filter     Filter
+	// All traverses all the pages of the timeline instead of displaying a
+	// single page. It is implied by a non-empty filter.
+	All            bool
+	Format         Format
+	DisplayAppName bool
+}
+
+// Timeline displays the events returned by fetch. Either a single page is
+// displayed, or all the pages are traversed and the events matching the
+// filter are displayed or exported.
+func Timeline(ctx context.Context, fetch PageFetcher, opts TimelineOpts) error {
+	if opts.Format == "" {
+		opts.Format = FormatText
+	}
+	switch opts.Format {
+	case FormatText, FormatJSON, FormatCSV:
+	default:
+		return errgo.Newf("invalid format '%s', must be %s, %s or %s", opts.Format, FormatText, FormatJSON, FormatCSV)
+	}
+
+	if !opts.All && opts.Filter.IsEmpty() {
+		events, pagination, err := fetch(ctx, opts.Pagination)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		if opts.Format != FormatText {
+			return Export(os.Stdout, events, opts.Format)
+		}
+		return DisplayTimeline(events, pagination, DisplayTimelineOpts{DisplayAppName: opts.DisplayAppName})
+	}
+
+	events, err := FetchAll(ctx, fetch, opts.Filter)
+	if err != nil {
+		return errgo.Notef(err, "fail to fetch the events")
+	}
+	if opts.Format != FormatText {
+		return Export(os.Stdout, events, opts.Format)
+	}
+	return DisplayTimeline(events, scalingo.PaginationMeta{}, DisplayTimelineOpts{
+		DisplayAppName: opts.DisplayAppName,
+		HidePagination: true,
+	})
+}
+
+// FetchAll traverses the pages of events and returns the ones matching the
+// filter. As the events are sorted from the most recent, the traversal stops
+// as soon as an event older than the beginning of the filter is met.
+func FetchAll(ctx context.Context, fetch PageFetcher, filter Filter) (scalingo.Events, error) {
+	var res scalingo.Events
+	page := 1
+	for i := 0; page != 0 && i < maxAllPages; i++ {
+		events, pagination, err := fetch(ctx, scalingo.PaginationOpts{Page: page, PerPage: allPerPage})
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to fetch page %d", page)
+		}
+
+		tooOld := false
+		for _, event := range events {
+			if !filter.Since.IsZero() && event.GetEvent().CreatedAt.Before(filter.Since) {
+				tooOld = true
+				break
+			}
+			if filter.Match(event) {
+				res = append(res, event)
+			}
+		}
+		if tooOld || pagination.CurrentPage >= pagination.TotalPages {
+			break
+		}
+		page = pagination.NextPage
+	}
+	return res, nil
+}
+
+// exportedEvent is the representation of an event in the exports
+type exportedEvent struct {
+	ID          string                 `json:"id"`
+	CreatedAt   time.Time              `json:"created_at"`
+	Type        scalingo.EventTypeName `json:"type"`
+	AppName     string                 `json:"app_name,omitempty"`
+	User        scalingo.EventUser     `json:"user"`
+	Description string                 `json:"description"`
+	TypeData    json.RawMessage        `json:"type_data,omitempty"`
+}
+
+func newExportedEvent(event scalingo.DetailedEvent) exportedEvent {
+	ev := event.GetEvent()
+	return exportedEvent{
+		ID:          ev.ID,
+		CreatedAt:   ev.CreatedAt,
+		Type:        ev.Type,
+		AppName:     ev.AppName,
+		User:        ev.User,
+		Description: event.String(),
+		TypeData:    ev.RawTypeData,
+	}
+}
+
+// Export writes the events as JSON Lines or CSV
+func Export(w stdio.Writer, events scalingo.Events, format Format) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		for _, event := range events {
+			err := encoder.Encode(newExportedEvent(event))
+			if err != nil {
+				return errgo.Notef(err, "fail to encode event")
+			}
+		}
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		err := writer.Write([]string{"id", "created_at", "type", "app_name", "username", "email", "description"})
+		if err != nil {
+			return errgo.Notef(err, "fail to write CSV header")
+		}
+		for _, event := range events {
+			ev := newExportedEvent(event)
+			err := writer.Write([]string{
+				ev.ID, ev.CreatedAt.Format(time.RFC3339), string(ev.Type), ev.AppName,
+				ev.User.Username, ev.User.Email, ev.Description,
+			})
+			if err != nil {
+				return errgo.Notef(err, "fail to write CSV line")
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return errgo.Notef(err, "fail to write CSV")
+		}
+	default:
+		return errgo.Newf("unsupported export format '%s'", format)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// Filter selects the events of a timeline. A zero value field does not
+// filter anything.
+type Filter struct {
+	// Types of the events to keep
+	Types []scalingo.EventTypeName
+	// Users who did the events to keep, matched against their username or email
+	Users []string
+	// Since and Until bound the creation date of the events to keep
+	Since time.Time
+	Until time.Time
+}
+
+func (f Filter) IsEmpty() bool {
+	return len(f.Types) == 0 && len(f.Users) == 0 && f.Since.IsZero() && f.Until.IsZero()
+}
+
+func (f Filter) Match(event scalingo.DetailedEvent) bool {
+	ev := event.GetEvent()
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if ev.Type == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Users) > 0 {
+		found := false
+		for _, u := range f.Users {
+			if strings.EqualFold(ev.User.Username, u) || strings.EqualFold(ev.User.Email, u) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && ev.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && ev.CreatedAt.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// ParseDate parses a date given on the command line. It can either be:
+// - a date: 2023-04-01
+// - a date and a time: 2023-04-01T12:00:00Z
+// - a duration relative to now: 30d, 12h, 90m
+func ParseDate(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if duration, err := utils.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	return time.Time{}, errgo.Newf("invalid date '%s', use YYYY-MM-DD, an RFC 3339 date or a duration like 30d", value)
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestFilter_Match(t *testing.T) {
+	event := &scalingo.Event{
+		Type:      scalingo.EventEditVariables,
+		CreatedAt: time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC),
+		User:      scalingo.EventUser{Username: "john", Email: "john@example.com"},
+	}
+
+	tests := map[string]struct {
+		filter   Filter
+		expected bool
+	}{
+		"an empty filter matches everything": {
+			expected: true,
+		},
+		"it should match on the type": {
+			filter:   Filter{Types: []scalingo.EventTypeName{scalingo.EventScale, scalingo.EventEditVariables}},
+			expected: true,
+		},
+		"it should not match another type": {
+			filter:   Filter{Types: []scalingo.EventTypeName{scalingo.EventScale}},
+			expected: false,
+		},
+		"it should match on the user email": {
+			filter:   Filter{Users: []string{"John@example.com"}},
+			expected: true,
+		},
+		"it should match on the username": {
+			filter:   Filter{Users: []string{"john"}},
+			expected: true,
+		},
+		"it should not match another user": {
+			filter:   Filter{Users: []string{"jane"}},
+			expected: false,
+		},
+		"it should match inside the date range": {
+			filter: Filter{
+				Since: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expected: true,
+		},
+		"it should not match outside the date range": {
+			filter:   Filter{Since: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)},
+			expected: false,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.filter.Match(event))
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2023, 4, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it should parse a relative amount of days", func(t *testing.T) {
+		date, err := ParseDate("30d", now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 3, 11, 12, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("it should parse a duration", func(t *testing.T) {
+		date, err := ParseDate("2h", now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 4, 10, 10, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("it should parse an RFC 3339 date", func(t *testing.T) {
+		date, err := ParseDate("2023-03-01T10:00:00Z", now)
+		require.NoError(t, err)
+		assert.True(t, date.Equal(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("it should parse a day", func(t *testing.T) {
+		date, err := ParseDate("2023-03-01", now)
+		require.NoError(t, err)
+		assert.Equal(t, "2023-03-01", date.Format("2006-01-02"))
+	})
+
+	t.Run("it should fail with an invalid date", func(t *testing.T) {
+		_, err := ParseDate("last month", now)
+		assert.Error(t, err)
+	})
+}
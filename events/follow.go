@@ -0,0 +1,191 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+const (
+	// HookAllTypes is the event type of a hook executed for every event
+	HookAllTypes = "*"
+
+	DefaultFollowInterval = 10 * time.Second
+
+	// maxFollowPages is the maximal amount of pages of a source fetched by a
+	// poll to catch up with the events which happened since the previous one
+	maxFollowPages = 10
+)
+
+type FollowOpts struct {
+	// Sources are the timelines which are polled, e.g. one per application
+	Sources []PageFetcher
+	// Interval between two polls of the sources
+	Interval time.Duration
+	// Lines is the amount of past events displayed when starting to follow
+	Lines          int
+	DisplayAppName bool
+	// Hooks are the commands executed for each new event, indexed by event
+	// type. The hooks of HookAllTypes are executed for all the events.
+	Hooks map[scalingo.EventTypeName][]string
+}
+
+// Follow polls the sources and displays the new events as they arrive,
+// oldest first. The events are de-duplicated by ID. It only returns when the
+// context is canceled.
+func Follow(ctx context.Context, opts FollowOpts) error {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultFollowInterval
+	}
+	// seen are the IDs of the events returned by the latest poll of each
+	// source. The events of the previous polls are older, they are not
+	// returned anymore and their IDs do not need to be kept.
+	seen := make([]map[string]bool, len(opts.Sources))
+
+	events, err := pollSources(ctx, opts.Sources, seen)
+	if err != nil {
+		return errgo.Notef(err, "fail to fetch the events")
+	}
+	if len(events) > opts.Lines {
+		events = events[len(events)-opts.Lines:]
+	}
+	for _, event := range events {
+		fmt.Println(formatEvent(event, opts.DisplayAppName, 0, 0))
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		events, err := pollSources(ctx, opts.Sources, seen)
+		if err != nil {
+			// A temporary failure of the API must not stop the feed
+			io.Errorf("Fail to fetch the events: %v\n", err)
+			continue
+		}
+		for _, event := range events {
+			fmt.Println(formatEvent(event, opts.DisplayAppName, 0, 0))
+			runEventHooks(event, opts.Hooks)
+		}
+	}
+}
+
+// Latest displays the opts.Lines latest events of the sources, oldest first
+func Latest(ctx context.Context, opts FollowOpts) error {
+	events, err := pollSources(ctx, opts.Sources, make([]map[string]bool, len(opts.Sources)))
+	if err != nil {
+		return errgo.Notef(err, "fail to fetch the events")
+	}
+	if len(events) > opts.Lines {
+		events = events[len(events)-opts.Lines:]
+	}
+	for _, event := range events {
+		fmt.Println(formatEvent(event, opts.DisplayAppName, 0, 0))
+	}
+	return nil
+}
+
+// pollSources returns the events of all the sources which are not in seen,
+// sorted from the oldest, and replaces seen by the IDs of the events returned
+// by the sources. seen is only updated if all the sources are polled.
+func pollSources(ctx context.Context, sources []PageFetcher, seen []map[string]bool) (scalingo.Events, error) {
+	var res scalingo.Events
+	polled := make([]map[string]bool, len(sources))
+	for i, fetch := range sources {
+		events, err := pollSource(ctx, fetch, seen[i])
+		if err != nil {
+			return nil, errgo.Mask(err, errgo.Any)
+		}
+		polled[i] = map[string]bool{}
+		for _, event := range events {
+			id := event.GetEvent().ID
+			polled[i][id] = true
+			if !seen[i][id] {
+				res = append(res, event)
+			}
+		}
+	}
+	copy(seen, polled)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].GetEvent().CreatedAt.Before(res[j].GetEvent().CreatedAt)
+	})
+	return res, nil
+}
+
+// pollSource returns the latest events of the source. The first poll of the
+// source, with seen nil, only returns the first page. The next polls go
+// through the pages until an event already seen is found, so that a burst of
+// events between two polls is not missed.
+func pollSource(ctx context.Context, fetch PageFetcher, seen map[string]bool) (scalingo.Events, error) {
+	var res scalingo.Events
+	for page := 1; page <= maxFollowPages; page++ {
+		events, meta, err := fetch(ctx, scalingo.PaginationOpts{Page: page, PerPage: allPerPage})
+		if err != nil {
+			return nil, errgo.Mask(err, errgo.Any)
+		}
+		res = append(res, events...)
+		if seen == nil || len(events) == 0 || page >= meta.TotalPages {
+			return res, nil
+		}
+		for _, event := range events {
+			if seen[event.GetEvent().ID] {
+				return res, nil
+			}
+		}
+	}
+	io.Warningf("More than %d events happened since the last poll, the oldest ones are not displayed\n", maxFollowPages*allPerPage)
+	return res, nil
+}
+
+// runEventHooks executes the hooks configured for the type of the event. The
+// event is given as JSON on the standard input of the hook, and its type, ID
+// and application are available in the environment.
+func runEventHooks(event scalingo.DetailedEvent, hooks map[scalingo.EventTypeName][]string) {
+	ev := event.GetEvent()
+	commands := append([]string{}, hooks[ev.Type]...)
+	commands = append(commands, hooks[HookAllTypes]...)
+	if len(commands) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(newExportedEvent(event))
+	if err != nil {
+		debug.Println("[Events] Fail to encode the event for the hook:", err)
+		return
+	}
+
+	for _, command := range commands {
+		cmd := utils.ShellCommand(command)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(),
+			"SCALINGO_EVENT_ID="+ev.ID,
+			"SCALINGO_EVENT_TYPE="+string(ev.Type),
+			"SCALINGO_EVENT_APP="+ev.AppName,
+		)
+		err := cmd.Run()
+		if err != nil {
+			io.Errorf("Hook '%s' failed for event %s: %v\n", command, ev.ID, err)
+			config.C.Logger.Printf("event hook '%s' failed: %v\n", command, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// timelineSource is a timeline of events, the most recent first like on the
+// Scalingo API
+type timelineSource struct {
+	events scalingo.Events
+}
+
+func (s *timelineSource) publish(amount int) {
+	for i := 0; i < amount; i++ {
+		id := len(s.events) + 1
+		event := &scalingo.Event{ID: fmt.Sprintf("event-%d", id), CreatedAt: time.Unix(int64(id), 0)}
+		s.events = append(scalingo.Events{event}, s.events...)
+	}
+}
+
+func (s *timelineSource) fetch(ctx context.Context, opts scalingo.PaginationOpts) (scalingo.Events, scalingo.PaginationMeta, error) {
+	meta := scalingo.PaginationMeta{CurrentPage: opts.Page, TotalPages: (len(s.events) + opts.PerPage - 1) / opts.PerPage}
+	start := (opts.Page - 1) * opts.PerPage
+	if start >= len(s.events) {
+		return scalingo.Events{}, meta, nil
+	}
+	end := start + opts.PerPage
+	if end > len(s.events) {
+		end = len(s.events)
+	}
+	return s.events[start:end], meta, nil
+}
+
+func TestPollSources(t *testing.T) {
+	ctx := context.Background()
+	source := &timelineSource{}
+	source.publish(150)
+	seen := make([]map[string]bool, 1)
+
+	// The first poll only fetches the first page
+	events, err := pollSources(ctx, []PageFetcher{source.fetch}, seen)
+	require.NoError(t, err)
+	require.Len(t, events, allPerPage)
+	assert.Equal(t, "event-51", events[0].GetEvent().ID)
+	assert.Equal(t, "event-150", events[allPerPage-1].GetEvent().ID)
+
+	// A burst larger than a page is fetched until an event already seen
+	source.publish(250)
+	events, err = pollSources(ctx, []PageFetcher{source.fetch}, seen)
+	require.NoError(t, err)
+	require.Len(t, events, 250)
+	assert.Equal(t, "event-151", events[0].GetEvent().ID)
+	assert.Equal(t, "event-400", events[249].GetEvent().ID)
+	assert.Len(t, seen[0], 3*allPerPage)
+
+	// Only the events of the latest poll are kept
+	events, err = pollSources(ctx, []PageFetcher{source.fetch}, seen)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Len(t, seen[0], allPerPage)
+}
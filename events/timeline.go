@@ -10,6 +10,9 @@ import (
 
 type DisplayTimelineOpts struct {
 	DisplayAppName bool
+	// HidePagination does not display the pagination footer, when all the
+	// pages have been fetched
+	HidePagination bool
 }
 
 func DisplayTimeline(events scalingo.Events, pagination scalingo.PaginationMeta, opts DisplayTimelineOpts) error {
@@ -25,43 +28,53 @@ func DisplayTimeline(events scalingo.Events, pagination scalingo.PaginationMeta,
 	}
 
 	for _, event := range events {
-		t := event.PrintableType()
-		if len(t) < longestEventName {
-			for len(t) != longestEventName {
-				t += " "
-			}
+		fmt.Println(formatEvent(event, opts.DisplayAppName, longestEventName, longestAppName))
+	}
+	if opts.HidePagination {
+		fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Events: %d", len(events))))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
+	return nil
+}
+
+// formatEvent returns the line displaying the event in a timeline. The type
+// and the application name are padded to the given lengths to align the
+// events of a timeline.
+func formatEvent(event scalingo.DetailedEvent, displayAppName bool, longestEventName, longestAppName int) string {
+	t := event.PrintableType()
+	if len(t) < longestEventName {
+		for len(t) != longestEventName {
+			t += " "
 		}
+	}
 
-		app := event.GetEvent().AppName
-		if opts.DisplayAppName && len(app) > 0 {
-			if len(app) < longestAppName {
-				for len(app) != longestAppName {
-					app += " "
-				}
+	app := event.GetEvent().AppName
+	if displayAppName && len(app) > 0 {
+		if len(app) < longestAppName {
+			for len(app) != longestAppName {
+				app += " "
 			}
-
-			fmt.Printf(
-				"* %s - %s - %s - %s %s\n",
-				io.Yellow(event.When()),
-				io.Green(t),
-				io.LightGray(app),
-				event.String(),
-				io.BoldBlue(
-					fmt.Sprintf("<%s>", event.Who()),
-				),
-			)
-		} else {
-			fmt.Printf(
-				"* %s - %s - %s %s\n",
-				io.Yellow(event.When()),
-				io.Green(t),
-				event.String(),
-				io.BoldBlue(
-					fmt.Sprintf("<%s>", event.Who()),
-				),
-			)
 		}
+
+		return fmt.Sprintf(
+			"* %s - %s - %s - %s %s",
+			io.Yellow(event.When()),
+			io.Green(t),
+			io.LightGray(app),
+			event.String(),
+			io.BoldBlue(
+				fmt.Sprintf("<%s>", event.Who()),
+			),
+		)
 	}
-	fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
-	return nil
+	return fmt.Sprintf(
+		"* %s - %s - %s %s",
+		io.Yellow(event.When()),
+		io.Green(t),
+		event.String(),
+		io.BoldBlue(
+			fmt.Sprintf("<%s>", event.Who()),
+		),
+	)
 }
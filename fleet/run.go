@@ -0,0 +1,179 @@
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+)
+
+// DefaultConcurrency is the amount of applications handled in parallel
+const DefaultConcurrency = 4
+
+// RunOpts are the parameters of Run
+type RunOpts struct {
+	Apps []string
+	// Args are the arguments of the CLI, os.Args without the selector flags.
+	// The command is run once per application with --app prepended to them.
+	Args []string
+	// Region is given to each command so that all the applications are looked
+	// up in the same region
+	Region      string
+	Concurrency int
+}
+
+type result struct {
+	App      string
+	Output   string
+	Err      error
+	Duration time.Duration
+}
+
+// Run executes the command once per application, in separate processes of the
+// CLI. A failure does not stop the other applications. The output of each
+// application is displayed once its command is done, followed by a table
+// summarizing the results. An error is returned if the command failed for at
+// least one application.
+func Run(ctx context.Context, opts RunOpts) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return errgo.Notef(err, "fail to find the CLI executable")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	io.Statusf("Running '%s' on %d apps\n", strings.Join(opts.Args[1:], " "), len(opts.Apps))
+
+	results := make([]result, len(opts.Apps))
+	outputMutex := &sync.Mutex{}
+	semaphore := make(chan struct{}, opts.Concurrency)
+	wg := &sync.WaitGroup{}
+	for i, app := range opts.Apps {
+		wg.Add(1)
+		go func(i int, app string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = runApp(ctx, executable, CommandArgs(opts.Args, opts.Region, app))
+			results[i].App = app
+
+			outputMutex.Lock()
+			defer outputMutex.Unlock()
+			displayResult(results[i])
+		}(i, app)
+	}
+	wg.Wait()
+
+	failures := 0
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"App", "Result", "Duration", "Error"})
+	for _, result := range results {
+		status := io.Green("OK")
+		errorMessage := ""
+		if result.Err != nil {
+			failures++
+			status = io.BoldRed("Failed")
+			errorMessage = lastLine(result.Output)
+			if errorMessage == "" {
+				errorMessage = result.Err.Error()
+			}
+		}
+		t.Append([]string{result.App, status, result.Duration.Round(time.Second).String(), errorMessage})
+	}
+	fmt.Println()
+	t.Render()
+
+	if failures > 0 {
+		return errgo.Newf("the command failed for %d of the %d apps", failures, len(results))
+	}
+	return nil
+}
+
+func runApp(ctx context.Context, executable string, args []string) result {
+	startedAt := time.Now()
+	output := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, executable, args[1:]...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// No confirmation can be answered for several applications at once
+	cmd.Stdin = nil
+	err := cmd.Run()
+
+	return result{Output: output.String(), Err: err, Duration: time.Since(startedAt)}
+}
+
+func displayResult(result result) {
+	if result.Err != nil {
+		io.Errorf("[%s] Failed (%v)\n", result.App, result.Err)
+	} else {
+		io.Statusf("[%s] Done\n", result.App)
+	}
+	output := strings.TrimRight(result.Output, "\n")
+	if output != "" {
+		fmt.Println(io.Indent(output, 7))
+	}
+}
+
+// CommandArgs returns the arguments of the CLI running the command for app in
+// region: args with the global flags --region and --app prepended
+func CommandArgs(args []string, region, app string) []string {
+	commandArgs := make([]string, 0, len(args)+4)
+	commandArgs = append(commandArgs, args[0])
+	if region != "" {
+		commandArgs = append(commandArgs, "--region", region)
+	}
+	commandArgs = append(commandArgs, "--app", app)
+	return append(commandArgs, args[1:]...)
+}
+
+// RemoveFlags returns args without the flags named names and their values.
+// The flags are expected to take a value, given as a separate argument or
+// after '='. The arguments after '--' are kept untouched.
+func RemoveFlags(args []string, names ...string) []string {
+	isRemoved := func(arg string) (bool, bool) {
+		for _, name := range names {
+			for _, prefix := range []string{"--", "-"} {
+				if arg == prefix+name {
+					return true, true
+				}
+				if strings.HasPrefix(arg, prefix+name+"=") {
+					return true, false
+				}
+			}
+		}
+		return false, false
+	}
+
+	kept := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			return append(kept, args[i:]...)
+		}
+		removed, withValue := isRemoved(args[i])
+		if !removed {
+			kept = append(kept, args[i])
+			continue
+		}
+		if withValue {
+			i++
+		}
+	}
+	return kept
+}
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
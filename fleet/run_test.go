@@ -0,0 +1,53 @@
+package fleet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveFlags(t *testing.T) {
+	tests := map[string]struct {
+		args         []string
+		expectedArgs []string
+	}{
+		"a flag with a separate value": {
+			args:         []string{"scalingo", "restart", "--apps", "app1,app2", "web"},
+			expectedArgs: []string{"scalingo", "restart", "web"},
+		},
+		"a flag with its value after '='": {
+			args:         []string{"scalingo", "env-set", "--apps-match=prefix-*", "KEY=value"},
+			expectedArgs: []string{"scalingo", "env-set", "KEY=value"},
+		},
+		"a flag with a single dash": {
+			args:         []string{"scalingo", "scale", "-apps-from", "apps.txt", "-apps-concurrency", "2", "web:2"},
+			expectedArgs: []string{"scalingo", "scale", "web:2"},
+		},
+		"the other flags are kept": {
+			args:         []string{"scalingo", "--region", "osc-fr1", "restart", "--apps", "app1", "--app-name", "x"},
+			expectedArgs: []string{"scalingo", "--region", "osc-fr1", "restart", "--app-name", "x"},
+		},
+		"the arguments after '--' are kept": {
+			args:         []string{"scalingo", "env-set", "--apps", "app1", "--", "--apps"},
+			expectedArgs: []string{"scalingo", "env-set", "--", "--apps"},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expectedArgs, RemoveFlags(test.args, "apps", "apps-from", "apps-match", "apps-concurrency"))
+		})
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	t.Run("the region and the app are given as global flags", func(t *testing.T) {
+		args := CommandArgs([]string{"scalingo", "restart", "web"}, "osc-fr1", "my-app")
+		assert.Equal(t, []string{"scalingo", "--region", "osc-fr1", "--app", "my-app", "restart", "web"}, args)
+	})
+
+	t.Run("without region", func(t *testing.T) {
+		args := CommandArgs([]string{"scalingo", "restart"}, "", "my-app")
+		assert.Equal(t, []string{"scalingo", "--app", "my-app", "restart"}, args)
+	})
+}
@@ -0,0 +1,106 @@
+// Package fleet runs a command of the CLI against several applications
+package fleet
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/utils"
+)
+
+// Selector selects the applications a command is run against. The
+// applications selected by each field are merged.
+type Selector struct {
+	// Apps is a comma separated list of applications
+	Apps string
+	// AppsFrom is the path of a file listing the applications, one per line
+	AppsFrom string
+	// AppsMatch is a glob pattern matched against the name of the applications
+	// of the current region, e.g. 'prefix-*'
+	AppsMatch string
+}
+
+// IsSet returns true if at least one way of selecting the applications is used
+func (s Selector) IsSet() bool {
+	return s.Apps != "" || s.AppsFrom != "" || s.AppsMatch != ""
+}
+
+// Resolve returns the names of the selected applications, without duplicates
+func (s Selector) Resolve(ctx context.Context) ([]string, error) {
+	if s.AppsMatch != "" {
+		_, err := path.Match(s.AppsMatch, "")
+		if err != nil {
+			return nil, errgo.Newf("invalid pattern '%s'", s.AppsMatch)
+		}
+	}
+
+	apps := splitAppsList(s.Apps)
+	if s.AppsFrom != "" {
+		fileApps, err := utils.ReadAppsFile(s.AppsFrom)
+		if err != nil {
+			return nil, errgo.Mask(err)
+		}
+		apps = append(apps, fileApps...)
+	}
+	if s.AppsMatch != "" {
+		c, err := config.ScalingoClient(ctx)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to get Scalingo client")
+		}
+		regionApps, err := c.AppsList(ctx)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to list the applications")
+		}
+		names := make([]string, 0, len(regionApps))
+		for _, app := range regionApps {
+			names = append(names, app.Name)
+		}
+		matching := matchApps(names, s.AppsMatch)
+		if len(matching) == 0 {
+			return nil, errgo.Newf("no application matches '%s'", s.AppsMatch)
+		}
+		apps = append(apps, matching...)
+	}
+
+	return uniqueApps(apps), nil
+}
+
+func splitAppsList(list string) []string {
+	apps := []string{}
+	for _, app := range strings.Split(list, ",") {
+		app = strings.TrimSpace(app)
+		if app != "" {
+			apps = append(apps, app)
+		}
+	}
+	return apps
+}
+
+// matchApps returns the names matching the glob pattern. The pattern must be
+// valid.
+func matchApps(names []string, pattern string) []string {
+	matching := []string{}
+	for _, name := range names {
+		if ok, _ := path.Match(pattern, name); ok {
+			matching = append(matching, name)
+		}
+	}
+	return matching
+}
+
+func uniqueApps(apps []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, app := range apps {
+		if seen[app] {
+			continue
+		}
+		seen[app] = true
+		unique = append(unique, app)
+	}
+	return unique
+}
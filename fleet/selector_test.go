@@ -0,0 +1,48 @@
+package fleet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAppsList(t *testing.T) {
+	assert.Equal(t, []string{"app1", "app2"}, splitAppsList(" app1, ,app2,"))
+	assert.Equal(t, []string{}, splitAppsList(""))
+}
+
+func TestMatchApps(t *testing.T) {
+	names := []string{"prefix-api", "prefix-front", "other-prefix-api", "prefix"}
+
+	tests := map[string]struct {
+		pattern      string
+		expectedApps []string
+	}{
+		"a prefix": {
+			pattern:      "prefix-*",
+			expectedApps: []string{"prefix-api", "prefix-front"},
+		},
+		"a suffix": {
+			pattern:      "*-api",
+			expectedApps: []string{"prefix-api", "other-prefix-api"},
+		},
+		"an exact name": {
+			pattern:      "prefix",
+			expectedApps: []string{"prefix"},
+		},
+		"no match": {
+			pattern:      "staging-*",
+			expectedApps: []string{},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expectedApps, matchApps(names, test.pattern))
+		})
+	}
+}
+
+func TestUniqueApps(t *testing.T) {
+	assert.Equal(t, []string{"app1", "app2"}, uniqueApps([]string{"app1", "app2", "app1"}))
+}
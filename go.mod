@@ -26,9 +26,11 @@ require (
 	github.com/stvp/rollbar v0.5.1
 	github.com/urfave/cli/v2 v2.24.2
 	golang.org/x/crypto v0.7.0
+	golang.org/x/net v0.9.0
 	golang.org/x/term v0.7.0
 	golang.org/x/text v0.9.0
 	gopkg.in/errgo.v1 v1.0.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -63,9 +65,7 @@ require (
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
 	golang.org/x/mod v0.9.0 // indirect
-	golang.org/x/net v0.9.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
 	golang.org/x/tools v0.7.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,323 @@
+package regionmigrations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+	httpclient "github.com/Scalingo/go-scalingo/v6/http"
+	"github.com/Scalingo/go-utils/errors/v2"
+)
+
+type CheckLevel string
+
+const (
+	// CheckLevelOK means the element is migrated without any action
+	CheckLevelOK CheckLevel = "ok"
+	// CheckLevelAttention means the element needs a manual action during or
+	// after the migration
+	CheckLevelAttention CheckLevel = "attention"
+	// CheckLevelBlocking means the migration cannot succeed until the problem
+	// is solved
+	CheckLevelBlocking CheckLevel = "blocking"
+)
+
+// CheckItem is the result of the inspection of an element of the application
+type CheckItem struct {
+	Category string
+	Subject  string
+	Level    CheckLevel
+	Details  string
+}
+
+// hostnameRegexp matches the hostnames in an environment variable value
+var hostnameRegexp = regexp.MustCompile(`(?i)[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+`)
+
+// Check inspects locally the application and its destination region, and
+// lists the elements which will need manual attention during a migration.
+// Nothing is created: neither the migration nor its preflight step are run.
+// An error is returned if a blocking problem is found.
+func Check(ctx context.Context, app, destination string) error {
+	if destination == config.C.ScalingoRegion {
+		return errgo.Newf("the application is already in the region %s", destination)
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get scalingo client")
+	}
+	destinationClient, err := config.ScalingoClientForRegion(ctx, destination)
+	if err != nil {
+		return errgo.Notef(err, "fail to get scalingo client for the region %s", destination)
+	}
+
+	_, err = c.AppsShow(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the application")
+	}
+
+	items := []CheckItem{}
+	checks := []func(context.Context, *scalingo.Client, *scalingo.Client, string) ([]CheckItem, error){
+		checkAddons, checkDomains, checkLogDrains, checkSCMLink, checkCronTasks, checkEnvironment,
+	}
+	for _, check := range checks {
+		checkItems, err := check(ctx, c, destinationClient, app)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		items = append(items, checkItems...)
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Category", "Subject", "Status", "Details"})
+	t.SetAutoMergeCells(true)
+	t.SetRowLine(true)
+	blocking, attention := 0, 0
+	for _, item := range items {
+		switch item.Level {
+		case CheckLevelBlocking:
+			blocking++
+		case CheckLevelAttention:
+			attention++
+		}
+		t.Append([]string{item.Category, item.Subject, formatCheckLevel(item.Level), item.Details})
+	}
+	t.Render()
+	fmt.Println()
+
+	if blocking > 0 {
+		return errgo.Newf("%d blocking problem(s) must be solved before migrating %s to %s", blocking, app, destination)
+	}
+	if attention > 0 {
+		io.Warningf("%d element(s) will need your attention during the migration of %s to %s.\n", attention, app, destination)
+	} else {
+		io.Statusf("Nothing prevents the migration of %s to %s.\n", app, destination)
+	}
+	io.Info(fmt.Sprintf("Create the migration with: scalingo --app %s migration-create --to %s", app, destination))
+	return nil
+}
+
+func formatCheckLevel(level CheckLevel) string {
+	switch level {
+	case CheckLevelBlocking:
+		return color.RedString("Blocking")
+	case CheckLevelAttention:
+		return color.YellowString("Attention")
+	}
+	return color.GreenString("OK")
+}
+
+func isNotFoundError(err error) bool {
+	rootError := errors.RootCause(err)
+	return httpclient.IsRequestFailedError(rootError) && rootError.(*httpclient.RequestFailedError).Code == 404
+}
+
+func checkAddons(ctx context.Context, c, destinationClient *scalingo.Client, app string) ([]CheckItem, error) {
+	addons, err := c.AddonsList(ctx, app)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the addons")
+	}
+	if len(addons) == 0 {
+		return nil, nil
+	}
+
+	providers, err := destinationClient.AddonProvidersList(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the addon providers of the destination region")
+	}
+	availableProviders := map[string]bool{}
+	for _, provider := range providers {
+		availableProviders[provider.ID] = true
+	}
+
+	items := []CheckItem{}
+	for _, addon := range addons {
+		if addon.AddonProvider == nil || addon.Plan == nil {
+			continue
+		}
+		item := CheckItem{
+			Category: "Addons",
+			Subject:  fmt.Sprintf("%s (%s)", addon.AddonProvider.Name, addon.Plan.DisplayName),
+			Level:    CheckLevelOK,
+			Details:  "Created in the destination region, data copied during the data step",
+		}
+
+		if !availableProviders[addon.AddonProvider.ID] {
+			item.Level = CheckLevelBlocking
+			item.Details = "This addon is not available in the destination region"
+			items = append(items, item)
+			continue
+		}
+
+		plans, err := destinationClient.AddonProviderPlansList(ctx, addon.AddonProvider.ID)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to list the plans of %s in the destination region", addon.AddonProvider.Name)
+		}
+		planAvailable := false
+		for _, plan := range plans {
+			if plan.Name == addon.Plan.Name && !plan.Disabled {
+				planAvailable = true
+				break
+			}
+		}
+		if !planAvailable {
+			item.Level = CheckLevelBlocking
+			item.Details = "This plan is not available in the destination region, change the plan with addons-upgrade"
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func checkDomains(ctx context.Context, c, _ *scalingo.Client, app string) ([]CheckItem, error) {
+	domains, err := c.DomainsList(ctx, app)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the domains")
+	}
+
+	items := []CheckItem{}
+	for _, domain := range domains {
+		items = append(items, CheckItem{
+			Category: "Domains",
+			Subject:  domain.Name,
+			Level:    CheckLevelAttention,
+			Details:  "The DNS records must be updated to point to the new application after the finalize step",
+		})
+
+		if !domain.SSL {
+			continue
+		}
+		item := CheckItem{Category: "SSL certificates", Subject: domain.Name, Level: CheckLevelOK}
+		switch {
+		case domain.LetsEncrypt:
+			item.Details = "Let's Encrypt certificate generated again once the DNS records are updated"
+		case domain.Validity.Before(time.Now()):
+			item.Level = CheckLevelAttention
+			item.Details = fmt.Sprintf("The certificate expired on %s, install a new one with domains-ssl", domain.Validity.Format("2006-01-02"))
+		default:
+			item.Details = fmt.Sprintf("Certificate copied, valid until %s", domain.Validity.Format("2006-01-02"))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func checkLogDrains(ctx context.Context, c, _ *scalingo.Client, app string) ([]CheckItem, error) {
+	drains, err := c.LogDrainsList(ctx, app)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the log drains")
+	}
+
+	items := []CheckItem{}
+	for _, drain := range drains {
+		items = append(items, CheckItem{
+			Category: "Log drains",
+			Subject:  drain.URL,
+			Level:    CheckLevelAttention,
+			Details:  "Log drains are not migrated, add it again on the new application with log-drains-add",
+		})
+	}
+	return items, nil
+}
+
+func checkSCMLink(ctx context.Context, c, _ *scalingo.Client, app string) ([]CheckItem, error) {
+	link, err := c.SCMRepoLinkShow(ctx, app)
+	if isNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get the integration link")
+	}
+
+	items := []CheckItem{{
+		Category: "SCM link",
+		Subject:  fmt.Sprintf("%s/%s (%s)", link.Owner, link.Repo, link.SCMType),
+		Level:    CheckLevelOK,
+		Details:  "The link is imported on the new application",
+	}}
+	if link.DeployReviewAppsEnabled {
+		items = append(items, CheckItem{
+			Category: "SCM link",
+			Subject:  "Review apps",
+			Level:    CheckLevelAttention,
+			Details:  "Existing review apps are not migrated, they are created again for the next pull requests",
+		})
+	}
+	return items, nil
+}
+
+func checkCronTasks(ctx context.Context, c, _ *scalingo.Client, app string) ([]CheckItem, error) {
+	cronTasks, err := c.CronTasksGet(ctx, app)
+	if isNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get the cron tasks")
+	}
+
+	items := []CheckItem{}
+	for _, job := range cronTasks.Jobs {
+		items = append(items, CheckItem{
+			Category: "Cron tasks",
+			Subject:  job.Command,
+			Level:    CheckLevelAttention,
+			Details:  "Deployed with the application, make sure the task can run in both regions during the migration",
+		})
+	}
+	return items, nil
+}
+
+func checkEnvironment(ctx context.Context, c, _ *scalingo.Client, app string) ([]CheckItem, error) {
+	variables, err := c.VariablesListWithoutAlias(ctx, app)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the environment variables")
+	}
+
+	items := []CheckItem{}
+	for _, variable := range variables {
+		// The variables of the platform and of the addons are updated by the migration
+		if strings.HasPrefix(variable.Name, "SCALINGO_") {
+			continue
+		}
+		hostnames := RegionHostnames(variable.Value, config.C.ScalingoRegion)
+		if len(hostnames) == 0 {
+			continue
+		}
+		items = append(items, CheckItem{
+			Category: "Environment",
+			Subject:  variable.Name,
+			Level:    CheckLevelAttention,
+			Details:  fmt.Sprintf("References hostnames of the region %s: %s", config.C.ScalingoRegion, strings.Join(hostnames, ", ")),
+		})
+	}
+	return items, nil
+}
+
+// RegionHostnames returns the hostnames of value which are specific to the
+// region, i.e. which have the region name as one of their labels
+func RegionHostnames(value, region string) []string {
+	if region == "" {
+		return nil
+	}
+
+	hostnames := []string{}
+	for _, hostname := range hostnameRegexp.FindAllString(value, -1) {
+		for _, label := range strings.Split(hostname, ".") {
+			if strings.EqualFold(label, region) {
+				hostnames = append(hostnames, hostname)
+				break
+			}
+		}
+	}
+	return hostnames
+}
@@ -21,6 +21,9 @@ type RefreshOpts struct {
 	ExpectedStatuses []scalingo.RegionMigrationStatus
 	HiddenSteps      []string
 	CurrentStep      scalingo.RegionMigrationStep
+	// SkipNextStepHints does not explain how to run the next step when the
+	// watched step succeeds, when the steps are chained
+	SkipNextStepHints bool
 }
 
 type Refresher struct {
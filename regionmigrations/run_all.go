@@ -0,0 +1,364 @@
+package regionmigrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	// DefaultBatchConcurrency is the amount of migrations run in parallel in
+	// batch mode
+	DefaultBatchConcurrency = 3
+
+	batchRefreshInterval = 5 * time.Second
+	batchMaxErrors       = 10
+)
+
+var errStepCanceled = errgo.New("step canceled")
+
+// NextStep returns the step to run after a migration reached status. finished
+// is true if the migration is over. An error is returned if the migration
+// cannot continue from this status.
+func NextStep(status scalingo.RegionMigrationStatus) (step scalingo.RegionMigrationStep, finished bool, err error) {
+	switch status {
+	case scalingo.RegionMigrationStatusCreated:
+		return scalingo.RegionMigrationStepPreflight, false, nil
+	case scalingo.RegionMigrationStatusPreflightSuccess:
+		return scalingo.RegionMigrationStepPrepare, false, nil
+	case scalingo.RegionMigrationStatusPrepared:
+		return scalingo.RegionMigrationStepData, false, nil
+	case scalingo.RegionMigrationStatusDataMigrated:
+		return scalingo.RegionMigrationStepFinalize, false, nil
+	case scalingo.RegionMigrationStatusDone:
+		return "", true, nil
+	case scalingo.RegionMigrationStatusError:
+		return "", false, errgo.New("the migration failed, abort it with migration-abort before retrying")
+	}
+	return "", false, errgo.Newf("the migration cannot continue from the status '%s'", status)
+}
+
+// isOngoing returns true if a migration with this status can be resumed
+func isOngoing(status scalingo.RegionMigrationStatus) bool {
+	switch status {
+	case scalingo.RegionMigrationStatusCreated, scalingo.RegionMigrationStatusPreflightSuccess,
+		scalingo.RegionMigrationStatusRunning, scalingo.RegionMigrationStatusPrepared,
+		scalingo.RegionMigrationStatusDataMigrated, scalingo.RegionMigrationStatusError:
+		return true
+	}
+	return false
+}
+
+// stepStatuses are the statuses at which a migration stops after a step
+var stepStatuses = []scalingo.RegionMigrationStatus{
+	scalingo.RegionMigrationStatusPreflightSuccess,
+	scalingo.RegionMigrationStatusPreflightError,
+	scalingo.RegionMigrationStatusPrepared,
+	scalingo.RegionMigrationStatusDataMigrated,
+	scalingo.RegionMigrationStatusAborted,
+}
+
+// stepRunner runs a step of a migration and returns the migration once the
+// step is over. It is also used with an empty step to wait for the end of a
+// running step.
+type stepRunner func(migration scalingo.RegionMigration, step scalingo.RegionMigrationStep) (scalingo.RegionMigration, error)
+
+// chainSteps runs the steps of a migration from its current status until it
+// is done
+func chainSteps(migration scalingo.RegionMigration, run stepRunner) (scalingo.RegionMigration, error) {
+	for {
+		var step scalingo.RegionMigrationStep
+		if migration.Status != scalingo.RegionMigrationStatusRunning {
+			var finished bool
+			var err error
+			step, finished, err = NextStep(migration.Status)
+			if err != nil {
+				return migration, errgo.Mask(err)
+			}
+			if finished {
+				return migration, nil
+			}
+		}
+
+		var err error
+		migration, err = run(migration, step)
+		if err != nil {
+			return migration, errgo.Mask(err, errgo.Any)
+		}
+	}
+}
+
+// findResumableMigration returns the migration of the application to resume:
+// migrationID if given, the most recent ongoing migration otherwise. If there
+// is none and destination is set, a migration is created.
+func findResumableMigration(ctx context.Context, c *scalingo.Client, app, migrationID, destination string) (scalingo.RegionMigration, error) {
+	if migrationID != "" {
+		migration, err := c.ShowRegionMigration(ctx, app, migrationID)
+		if err != nil {
+			return migration, errgo.Notef(err, "fail to show region migration")
+		}
+		return migration, nil
+	}
+
+	migrations, err := c.ListRegionMigrations(ctx, app)
+	if err != nil {
+		return scalingo.RegionMigration{}, errgo.Notef(err, "fail to list migrations")
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].StartedAt.After(migrations[j].StartedAt)
+	})
+	if len(migrations) > 0 {
+		if migrations[0].Status == scalingo.RegionMigrationStatusDone {
+			return migrations[0], nil
+		}
+		if isOngoing(migrations[0].Status) {
+			return migrations[0], nil
+		}
+	}
+
+	if destination == "" {
+		return scalingo.RegionMigration{}, errgo.Newf("no ongoing migration for %s, create one with migration-create or give the destination region", app)
+	}
+	migration, err := c.CreateRegionMigration(ctx, app, scalingo.RegionMigrationParams{Destination: destination})
+	if err != nil {
+		return migration, errgo.Notef(err, "fail to create migration")
+	}
+	return migration, nil
+}
+
+// RunAllStepsOpts are the parameters of RunAllSteps
+type RunAllStepsOpts struct {
+	// MigrationID is the migration to run, the ongoing one of the app if empty
+	MigrationID string
+	// Destination is the region of the migration to create if the app has no
+	// ongoing migration
+	Destination string
+	// Yes runs the steps without asking for a confirmation
+	Yes bool
+}
+
+// RunAllSteps chains the preflight, prepare, data and finalize steps of a
+// migration, starting from its current status. It can be used to resume a
+// migration which has been interrupted.
+func RunAllSteps(ctx context.Context, app string, opts RunAllStepsOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get scalingo client")
+	}
+
+	migration, err := findResumableMigration(ctx, c, app, opts.MigrationID, opts.Destination)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if migration.Status == scalingo.RegionMigrationStatusDone {
+		io.Statusf("The migration %s of %s is already done\n", migration.ID, app)
+		return nil
+	}
+	io.Statusf("Running the migration %s of %s to %s from the status '%s'\n", migration.ID, app, migration.Destination, migration.Status)
+
+	migration, err = chainSteps(migration, func(migration scalingo.RegionMigration, step scalingo.RegionMigrationStep) (scalingo.RegionMigration, error) {
+		if step != "" && !opts.Yes && !ConfirmStep(migration, step) {
+			return migration, errStepCanceled
+		}
+
+		previousStepIDs := []string{}
+		for _, step := range migration.Steps {
+			previousStepIDs = append(previousStepIDs, step.ID)
+		}
+		if step != "" {
+			err := c.RunRegionMigrationStep(ctx, app, migration.ID, step)
+			if err != nil {
+				return migration, errgo.Notef(err, "fail to run %s step", step)
+			}
+		}
+
+		err := WatchMigration(ctx, c, app, migration.ID, RefreshOpts{
+			ExpectedStatuses:  stepStatuses,
+			HiddenSteps:       previousStepIDs,
+			CurrentStep:       step,
+			SkipNextStepHints: true,
+		})
+		if err != nil {
+			return migration, errgo.Notef(err, "fail to watch migration")
+		}
+
+		migration, err = c.ShowRegionMigration(ctx, app, migration.ID)
+		if err != nil {
+			return migration, errgo.Notef(err, "fail to show region migration")
+		}
+		return migration, nil
+	})
+	if errgo.Cause(err) == errStepCanceled {
+		fmt.Println("The current step has been canceled. You can resume the migration later with:")
+		fmt.Printf("scalingo --region %s --app %s migration-run --all-steps %s\n", migration.Source, app, migration.ID)
+		fmt.Println("If you want to abort the migration, run:")
+		fmt.Printf("scalingo --region %s --app %s migration-abort %s\n", migration.Source, app, migration.ID)
+		return nil
+	}
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	return nil
+}
+
+// BatchOpts are the parameters of RunBatch
+type BatchOpts struct {
+	Apps        []string
+	Destination string
+	Concurrency int
+	// ReportFile is the path of the JSON report written at the end, if set
+	ReportFile string
+}
+
+// BatchResult is the outcome of the migration of an application in batch mode
+type BatchResult struct {
+	App         string                         `json:"app"`
+	MigrationID string                         `json:"migration_id,omitempty"`
+	Destination string                         `json:"destination,omitempty"`
+	Status      scalingo.RegionMigrationStatus `json:"status,omitempty"`
+	Error       string                         `json:"error,omitempty"`
+	StartedAt   time.Time                      `json:"started_at"`
+	Duration    string                         `json:"duration"`
+}
+
+// RunBatch migrates several applications without any confirmation. Each
+// migration is resumed from its current status or created if the application
+// has no ongoing migration. A failed migration does not stop the others.
+func RunBatch(ctx context.Context, opts BatchOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get scalingo client")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]BatchResult, len(opts.Apps))
+	semaphore := make(chan struct{}, opts.Concurrency)
+	wg := &sync.WaitGroup{}
+	for i, app := range opts.Apps {
+		wg.Add(1)
+		go func(i int, app string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i] = runBatchMigration(ctx, c, app, opts.Destination)
+		}(i, app)
+	}
+	wg.Wait()
+
+	failures := 0
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"App", "Migration ID", "Status", "Duration", "Error"})
+	for _, result := range results {
+		if result.Error != "" {
+			failures++
+		}
+		t.Append([]string{result.App, result.MigrationID, formatMigrationStatus(result.Status), result.Duration, result.Error})
+	}
+	fmt.Println()
+	t.Render()
+
+	if opts.ReportFile != "" {
+		content, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return errgo.Notef(err, "fail to encode the report")
+		}
+		err = os.WriteFile(opts.ReportFile, content, 0644)
+		if err != nil {
+			return errgo.Notef(err, "fail to write the report")
+		}
+		io.Statusf("Report written to %s\n", opts.ReportFile)
+	}
+
+	if failures > 0 {
+		return errgo.Newf("%d of the %d migrations failed", failures, len(results))
+	}
+	return nil
+}
+
+func runBatchMigration(ctx context.Context, c *scalingo.Client, app, destination string) (result BatchResult) {
+	result = BatchResult{App: app, StartedAt: time.Now()}
+	defer func() {
+		result.Duration = time.Since(result.StartedAt).Round(time.Second).String()
+	}()
+
+	migration, err := findResumableMigration(ctx, c, app, "", destination)
+	if err != nil {
+		result.Error = err.Error()
+		io.Errorf("[%s] %v\n", app, err)
+		return result
+	}
+	result.MigrationID = migration.ID
+	result.Destination = migration.Destination
+	if destination != "" && migration.Destination != destination {
+		result.Status = migration.Status
+		result.Error = fmt.Sprintf("the ongoing migration %s is to %s", migration.ID, migration.Destination)
+		io.Errorf("[%s] %s\n", app, result.Error)
+		return result
+	}
+	io.Statusf("[%s] Migration %s to %s, current status: %s\n", app, migration.ID, migration.Destination, migration.Status)
+
+	migration, err = chainSteps(migration, func(migration scalingo.RegionMigration, step scalingo.RegionMigrationStep) (scalingo.RegionMigration, error) {
+		if step != "" {
+			io.Statusf("[%s] Running the %s step\n", app, step)
+			err := c.RunRegionMigrationStep(ctx, app, migration.ID, step)
+			if err != nil {
+				return migration, errgo.Notef(err, "fail to run %s step", step)
+			}
+		}
+		return waitForStep(ctx, c, app, migration.ID)
+	})
+	result.Status = migration.Status
+	if err != nil {
+		result.Error = err.Error()
+		io.Errorf("[%s] %v\n", app, err)
+		return result
+	}
+	io.Statusf("[%s] Migration done\n", app)
+	return result
+}
+
+// waitForStep polls the migration until it reaches the end of a step or until
+// ctx is canceled
+func waitForStep(ctx context.Context, c *scalingo.Client, app, migrationID string) (scalingo.RegionMigration, error) {
+	errCount := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return scalingo.RegionMigration{}, errgo.Notef(ctx.Err(), "stop waiting for the migration")
+		case <-time.After(batchRefreshInterval):
+		}
+
+		migration, err := c.ShowRegionMigration(ctx, app, migrationID)
+		if err != nil {
+			errCount++
+			if errCount >= batchMaxErrors {
+				return migration, errgo.Notef(err, "fail to get migration")
+			}
+			continue
+		}
+		errCount = 0
+
+		switch migration.Status {
+		case scalingo.RegionMigrationStatusDone, scalingo.RegionMigrationStatusError:
+			return migration, nil
+		}
+		for _, status := range stepStatuses {
+			if migration.Status == status {
+				return migration, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package regionmigrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestChainSteps(t *testing.T) {
+	// statusAfter is the status reached by the migration after each step
+	statusAfter := map[scalingo.RegionMigrationStep]scalingo.RegionMigrationStatus{
+		scalingo.RegionMigrationStepPreflight: scalingo.RegionMigrationStatusPreflightSuccess,
+		scalingo.RegionMigrationStepPrepare:   scalingo.RegionMigrationStatusPrepared,
+		scalingo.RegionMigrationStepData:      scalingo.RegionMigrationStatusDataMigrated,
+		scalingo.RegionMigrationStepFinalize:  scalingo.RegionMigrationStatusDone,
+	}
+
+	tests := map[string]struct {
+		status         scalingo.RegionMigrationStatus
+		failingStep    scalingo.RegionMigrationStep
+		expectedSteps  []scalingo.RegionMigrationStep
+		expectedStatus scalingo.RegionMigrationStatus
+		expectedError  string
+	}{
+		"a new migration runs all the steps": {
+			status: scalingo.RegionMigrationStatusCreated,
+			expectedSteps: []scalingo.RegionMigrationStep{
+				scalingo.RegionMigrationStepPreflight, scalingo.RegionMigrationStepPrepare,
+				scalingo.RegionMigrationStepData, scalingo.RegionMigrationStepFinalize,
+			},
+			expectedStatus: scalingo.RegionMigrationStatusDone,
+		},
+		"a prepared migration resumes at the data step": {
+			status:         scalingo.RegionMigrationStatusPrepared,
+			expectedSteps:  []scalingo.RegionMigrationStep{scalingo.RegionMigrationStepData, scalingo.RegionMigrationStepFinalize},
+			expectedStatus: scalingo.RegionMigrationStatusDone,
+		},
+		"a running migration is waited for before continuing": {
+			status:         scalingo.RegionMigrationStatusRunning,
+			expectedSteps:  []scalingo.RegionMigrationStep{"", scalingo.RegionMigrationStepFinalize},
+			expectedStatus: scalingo.RegionMigrationStatusDone,
+		},
+		"a done migration runs nothing": {
+			status:         scalingo.RegionMigrationStatusDone,
+			expectedSteps:  []scalingo.RegionMigrationStep{},
+			expectedStatus: scalingo.RegionMigrationStatusDone,
+		},
+		"a failing step stops the chain": {
+			status:        scalingo.RegionMigrationStatusPreflightSuccess,
+			failingStep:   scalingo.RegionMigrationStepData,
+			expectedSteps: []scalingo.RegionMigrationStep{scalingo.RegionMigrationStepPrepare, scalingo.RegionMigrationStepData},
+			expectedError: "the migration failed, abort it with migration-abort before retrying",
+		},
+		"an aborted migration cannot continue": {
+			status:        scalingo.RegionMigrationStatusAborted,
+			expectedSteps: []scalingo.RegionMigrationStep{},
+			expectedError: "the migration cannot continue from the status 'aborted'",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			steps := []scalingo.RegionMigrationStep{}
+			migration, err := chainSteps(scalingo.RegionMigration{Status: test.status}, func(migration scalingo.RegionMigration, step scalingo.RegionMigrationStep) (scalingo.RegionMigration, error) {
+				steps = append(steps, step)
+				switch {
+				case step == "":
+					// The running step is the data one
+					migration.Status = scalingo.RegionMigrationStatusDataMigrated
+				case step == test.failingStep:
+					migration.Status = scalingo.RegionMigrationStatusError
+				default:
+					migration.Status = statusAfter[step]
+				}
+				return migration, nil
+			})
+
+			assert.Equal(t, test.expectedSteps, steps)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, migration.Status)
+		})
+	}
+}
+
+func TestRunBatchMigration_Duration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/apps/my-app/region_migrations", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "migration-1", "destination": "osc-secnum-fr1", "status": "done"}]`))
+	}))
+	defer server.Close()
+
+	c, err := scalingo.New(context.Background(), scalingo.ClientConfig{
+		APIEndpoint:          server.URL,
+		StaticTokenGenerator: scalingo.NewStaticTokenGenerator("token"),
+	})
+	require.NoError(t, err)
+
+	result := runBatchMigration(context.Background(), c, "my-app", "")
+
+	assert.Equal(t, "migration-1", result.MigrationID)
+	assert.Equal(t, scalingo.RegionMigrationStatusDone, result.Status)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, "0s", result.Duration)
+}
+
+func TestWaitForStep_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := waitForStep(ctx, nil, "my-app", "migration-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context canceled")
+}
@@ -27,6 +27,12 @@ func WatchMigration(ctx context.Context, client *scalingo.Client, appID, migrati
 
 func migrationFinished(ctx context.Context, appID string, migration scalingo.RegionMigration, opts RefreshOpts) {
 	fmt.Printf("\n\n")
+	if opts.SkipNextStepHints {
+		switch migration.Status {
+		case scalingo.RegionMigrationStatusPreflightSuccess, scalingo.RegionMigrationStatusPrepared, scalingo.RegionMigrationStatusDataMigrated:
+			return
+		}
+	}
 	switch migration.Status {
 	case scalingo.RegionMigrationStatusDone:
 		showMigrationStatusSuccess(ctx, appID, migration)
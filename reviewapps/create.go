@@ -0,0 +1,176 @@
+package reviewapps
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/deployments"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// CreateOpts are the parameters of Create
+type CreateOpts struct {
+	Parent string
+	Name   string
+	// NoFollow does not stream the logs of the deployment
+	NoFollow bool
+}
+
+// Create creates a review app of the current Git branch without SCM
+// integration. The configuration of the parent application is copied: addons
+// plans, environment and collaborators, then the HEAD of the current branch is
+// deployed with an archive and the review app is scaled like its parent. With
+// NoFollow, the deployment is not awaited and the scale command to run once it
+// is done is printed instead. The link between the review app
+// and its parent is kept locally so that DestroyLocal can tear it down.
+//
+// The elements which could not be copied are listed at the end, they do not
+// stop the creation.
+func Create(ctx context.Context, opts CreateOpts) error {
+	repoDir, ok := utils.DetectGit()
+	if !ok {
+		return errgo.New("the current directory is not a Git repository")
+	}
+	branch, err := gitOutput(repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current branch")
+	}
+	commit, err := gitOutput(repoDir, "rev-parse", "HEAD")
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current commit")
+	}
+	if status, err := gitOutput(repoDir, "status", "--porcelain", "--untracked-files=no"); err == nil && status != "" {
+		io.Warning("Your working tree has uncommitted changes, only the committed ones are deployed.")
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	_, err = c.AppsShow(ctx, opts.Parent)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the parent application")
+	}
+
+	app, err := c.AppsCreate(ctx, scalingo.AppsCreateOpts{Name: opts.Name})
+	if err != nil {
+		return errgo.Notef(err, "fail to create the review app")
+	}
+	io.Statusf("Review app %s created from %s\n", app.Name, opts.Parent)
+
+	err = trackLocalReviewApp(LocalReviewApp{
+		Name:      app.Name,
+		Parent:    opts.Parent,
+		Region:    config.C.ScalingoRegion,
+		Branch:    branch,
+		Commit:    commit,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		io.Warning("Fail to keep track of the review app locally:", err)
+	}
+
+	notCopied := []string{}
+	notCopied = append(notCopied, apps.CopyAddons(ctx, c, c, opts.Parent, app.Name)...)
+	notCopied = append(notCopied, apps.CopyEnvironment(ctx, c, c, opts.Parent, app.Name)...)
+	notCopied = append(notCopied, apps.CopyCollaborators(ctx, c, c, opts.Parent, app.Name)...)
+	displayNotCopied(opts.Parent, notCopied)
+
+	archivePath, err := gitArchive(repoDir, app.Name)
+	if err != nil {
+		return errgo.Notef(err, "fail to create the archive of the current branch")
+	}
+	defer os.RemoveAll(filepath.Dir(archivePath))
+
+	io.Statusf("Deploying the branch %s (%s)\n", branch, commit[:7])
+	err = deployments.Deploy(ctx, app.Name, archivePath, commit, deployments.DeployOpts{NoFollow: opts.NoFollow})
+	if err != nil {
+		return errgo.Notef(err, "fail to deploy the review app")
+	}
+
+	// An application cannot be scaled before its first deployment
+	if opts.NoFollow {
+		scaleCommand, err := apps.FormationScaleCommand(ctx, c, opts.Parent, app.Name, config.C.ScalingoRegion)
+		if err != nil {
+			displayNotCopied(opts.Parent, []string{fmt.Sprintf("formation: %v", err)})
+		} else if scaleCommand != "" {
+			io.Infof("Apply the formation of %s once the review app is deployed with:\n", opts.Parent)
+			fmt.Println(io.Indent(scaleCommand, 7))
+		}
+		return nil
+	}
+	displayNotCopied(opts.Parent, apps.CopyFormation(ctx, c, c, opts.Parent, app.Name))
+	return nil
+}
+
+func displayNotCopied(parent string, notCopied []string) {
+	if len(notCopied) == 0 {
+		return
+	}
+	io.Warning("The following elements of", parent, "could not be copied:")
+	for _, element := range notCopied {
+		fmt.Println(io.Indent("- "+element, 7))
+	}
+}
+
+// DestroyLocal destroys a review app created with Create and forgets its link with
+// the parent application
+func DestroyLocal(ctx context.Context, name string, force bool) error {
+	reviewApp, ok, err := findLocalReviewApp(name)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if !ok {
+		return errgo.Newf("'%s' is not a review app created with review-app-create in the region %s", name, config.C.ScalingoRegion)
+	}
+
+	err = apps.Destroy(ctx, reviewApp.Name, force)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	err = untrackLocalReviewApp(reviewApp.Name)
+	if err != nil {
+		return errgo.Notef(err, "fail to forget the review app")
+	}
+	return nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errgo.Notef(err, "fail to execute git %s", strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitArchive creates a tar.gz archive of HEAD in a temporary directory
+func gitArchive(dir, name string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "scalingo-review-app-")
+	if err != nil {
+		return "", errgo.Notef(err, "fail to create a temporary directory")
+	}
+	archivePath := filepath.Join(tmpDir, name+".tar.gz")
+
+	_, err = gitOutput(dir, "archive", "--format=tar.gz", "--prefix="+name+"/", "--output="+archivePath, "HEAD")
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", errgo.Mask(err)
+	}
+	return archivePath, nil
+}
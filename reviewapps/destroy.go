@@ -0,0 +1,79 @@
+package reviewapps
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+)
+
+// DestroyOpts are the parameters of Destroy
+type DestroyOpts struct {
+	ListOpts
+	// DryRun only lists the review apps which would be destroyed
+	DryRun bool
+	// Force destroys the review apps without asking for a confirmation
+	Force bool
+}
+
+// Destroy destroys the review apps matching the options. A failure to destroy
+// a review app does not stop the destruction of the others.
+func Destroy(ctx context.Context, opts DestroyOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	reviewApps, err := Fetch(ctx, c, opts.ListOpts)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if len(reviewApps) == 0 {
+		io.Status("No review app to destroy.")
+		return nil
+	}
+
+	fmt.Println("The following review apps match:")
+	for _, ra := range reviewApps {
+		fmt.Printf("  - %s (parent: %s, PR #%s %s, created %s)\n",
+			ra.AppName, ra.ParentAppName, pullRequestNumber(ra.ReviewApp), pullRequestState(ra.ReviewApp), ra.CreatedAt.Local().Format("2006-01-02"),
+		)
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	if !opts.Force {
+		fmt.Printf("\n/!\\ You're going to delete %d review apps, this operation is irreversible.\nTo confirm type the number of review apps to delete: ", len(reviewApps))
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+		answer = strings.TrimSpace(answer)
+		if answer != fmt.Sprintf("%d", len(reviewApps)) {
+			return errgo.Newf("'%s' is not '%d', aborting…", answer, len(reviewApps))
+		}
+	}
+
+	failures := 0
+	for _, ra := range reviewApps {
+		err := c.AppsDestroy(ctx, ra.AppName, ra.AppName)
+		if err != nil {
+			failures++
+			io.Errorf("Fail to destroy %s: %v\n", ra.AppName, err)
+			continue
+		}
+		io.Status("Review app", ra.AppName, "has been deleted")
+	}
+
+	if failures > 0 {
+		return errgo.Newf("%d review app(s) could not be destroyed", failures)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package reviewapps
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	PullRequestStateOpen   = "open"
+	PullRequestStateClosed = "closed"
+
+	fetchConcurrency = 8
+	linksPerPage     = 100
+)
+
+// Filter selects review apps. A zero value field does not filter anything.
+type Filter struct {
+	// State of the pull request: PullRequestStateOpen or PullRequestStateClosed
+	State string
+	// OlderThan keeps the review apps created before now - OlderThan
+	OlderThan time.Duration
+}
+
+// Match returns true if the review app is selected by the filter
+func (f Filter) Match(ra *scalingo.ReviewApp, now time.Time) bool {
+	if f.State != "" && pullRequestState(ra) != f.State {
+		return false
+	}
+	if f.OlderThan != 0 && ra.CreatedAt.After(now.Add(-f.OlderThan)) {
+		return false
+	}
+	return true
+}
+
+// ValidateState checks the value of a pull request state given on the command line
+func ValidateState(state string) error {
+	if state != "" && state != PullRequestStateOpen && state != PullRequestStateClosed {
+		return errgo.Newf("invalid pull request state '%s', must be %s or %s", state, PullRequestStateOpen, PullRequestStateClosed)
+	}
+	return nil
+}
+
+func pullRequestState(ra *scalingo.ReviewApp) string {
+	if ra.PullRequest != nil && !ra.PullRequest.ClosedAt.IsZero() {
+		return PullRequestStateClosed
+	}
+	return PullRequestStateOpen
+}
+
+// ReviewApp is a review app with the URL of its application
+type ReviewApp struct {
+	*scalingo.ReviewApp
+	URL string
+}
+
+// ListOpts are the parameters of List and Fetch
+type ListOpts struct {
+	// App is the parent application, ignored if All is set
+	App string
+	// All selects the review apps of all the parent applications linked to a repository
+	All    bool
+	Filter Filter
+}
+
+func List(ctx context.Context, opts ListOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	reviewApps, err := Fetch(ctx, c, opts)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if len(reviewApps) == 0 {
+		if opts.All {
+			io.Status("No review app found.")
+		} else {
+			io.Statusf("No review app for '%s' or specified app is not a parent app.\n", opts.App)
+		}
+		return nil
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	header := []string{"App", "PR", "PR Branch", "PR State", "Created At", "Status", "URL"}
+	if opts.All {
+		header = append([]string{"Parent"}, header...)
+	}
+	t.SetHeader(header)
+	for _, ra := range reviewApps {
+		row := []string{
+			ra.AppName, pullRequestNumber(ra.ReviewApp), pullRequestBranch(ra.ReviewApp), pullRequestState(ra.ReviewApp),
+			ra.CreatedAt.Local().Format(utils.TimeFormat), deploymentStatus(ra.ReviewApp), ra.URL,
+		}
+		if opts.All {
+			row = append([]string{ra.ParentAppName}, row...)
+		}
+		t.Append(row)
+	}
+	t.Render()
+
+	return nil
+}
+
+// Fetch returns the review apps matching the options. The review apps of the
+// parent applications and their applications are fetched concurrently.
+func Fetch(ctx context.Context, c *scalingo.Client, opts ListOpts) ([]ReviewApp, error) {
+	parents := []string{opts.App}
+	if opts.All {
+		var err error
+		parents, err = linkedApps(ctx, c)
+		if err != nil {
+			return nil, errgo.Mask(err)
+		}
+	}
+
+	var (
+		lock       sync.Mutex
+		wg         sync.WaitGroup
+		reviewApps []ReviewApp
+		fetchErr   error
+	)
+	semaphore := make(chan struct{}, fetchConcurrency)
+	now := time.Now()
+
+	for _, parent := range parents {
+		wg.Add(1)
+		go func(parent string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			parentReviewApps, err := c.SCMRepoLinkReviewApps(ctx, parent)
+			<-semaphore
+			if err != nil {
+				lock.Lock()
+				fetchErr = errgo.Notef(err, "fail to get review apps of %s", parent)
+				lock.Unlock()
+				return
+			}
+
+			for _, ra := range parentReviewApps {
+				if !opts.Filter.Match(ra, now) {
+					continue
+				}
+				wg.Add(1)
+				go func(ra *scalingo.ReviewApp) {
+					defer wg.Done()
+					semaphore <- struct{}{}
+					app, err := c.AppsShow(ctx, ra.AppID)
+					<-semaphore
+
+					lock.Lock()
+					defer lock.Unlock()
+					if err != nil {
+						fetchErr = errgo.Notef(err, "fail to get app from review app")
+						return
+					}
+					reviewApps = append(reviewApps, ReviewApp{ReviewApp: ra, URL: app.URL})
+				}(ra)
+			}
+		}(parent)
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	sort.Slice(reviewApps, func(i, j int) bool {
+		if reviewApps[i].ParentAppName != reviewApps[j].ParentAppName {
+			return reviewApps[i].ParentAppName < reviewApps[j].ParentAppName
+		}
+		return reviewApps[i].CreatedAt.Before(reviewApps[j].CreatedAt)
+	})
+	return reviewApps, nil
+}
+
+// linkedApps returns the IDs of the applications linked to a repository
+func linkedApps(ctx context.Context, c *scalingo.Client) ([]string, error) {
+	apps := []string{}
+	for page := 1; ; page++ {
+		links, meta, err := c.SCMRepoLinkList(ctx, scalingo.PaginationOpts{Page: page, PerPage: linksPerPage})
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to list the repository links")
+		}
+		for _, link := range links {
+			apps = append(apps, link.AppID)
+		}
+		if meta.NextPage <= page {
+			return apps, nil
+		}
+	}
+}
+
+func pullRequestNumber(ra *scalingo.ReviewApp) string {
+	if ra.PullRequest == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", ra.PullRequest.Number)
+}
+
+func pullRequestBranch(ra *scalingo.ReviewApp) string {
+	if ra.PullRequest == nil {
+		return "-"
+	}
+	return ra.PullRequest.BranchName
+}
+
+func deploymentStatus(ra *scalingo.ReviewApp) string {
+	if ra.LastDeployment == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%v", ra.LastDeployment.Status)
+}
@@ -0,0 +1,53 @@
+package reviewapps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestFilter_Match(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	openReviewApp := &scalingo.ReviewApp{
+		CreatedAt:   now.AddDate(0, 0, -10),
+		PullRequest: &scalingo.PullRequest{Number: 1},
+	}
+	closedReviewApp := &scalingo.ReviewApp{
+		CreatedAt:   now.AddDate(0, 0, -2),
+		PullRequest: &scalingo.PullRequest{Number: 2, ClosedAt: now.AddDate(0, 0, -1)},
+	}
+
+	tests := map[string]struct {
+		filter    Filter
+		reviewApp *scalingo.ReviewApp
+		expected  bool
+	}{
+		"an empty filter matches everything": {
+			filter: Filter{}, reviewApp: closedReviewApp, expected: true,
+		},
+		"the state of an open pull request matches": {
+			filter: Filter{State: PullRequestStateOpen}, reviewApp: openReviewApp, expected: true,
+		},
+		"the state of a closed pull request does not match open": {
+			filter: Filter{State: PullRequestStateOpen}, reviewApp: closedReviewApp, expected: false,
+		},
+		"a review app without pull request is open": {
+			filter: Filter{State: PullRequestStateClosed}, reviewApp: &scalingo.ReviewApp{}, expected: false,
+		},
+		"an old review app matches older-than": {
+			filter: Filter{OlderThan: 7 * 24 * time.Hour}, reviewApp: openReviewApp, expected: true,
+		},
+		"a recent review app does not match older-than": {
+			filter: Filter{State: PullRequestStateClosed, OlderThan: 7 * 24 * time.Hour}, reviewApp: closedReviewApp, expected: false,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.filter.Match(test.reviewApp, now))
+		})
+	}
+}
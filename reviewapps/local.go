@@ -0,0 +1,94 @@
+package reviewapps
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+)
+
+var (
+	localReviewAppsFile = filepath.Join(config.C.ConfigDir, "review_apps.json")
+)
+
+// LocalReviewApp is a review app created from the CLI with `review-app-create`.
+// Without an SCM integration, the Scalingo API does not know the link between
+// a parent application and its review apps, it is kept locally.
+type LocalReviewApp struct {
+	Name      string    `json:"name"`
+	Parent    string    `json:"parent"`
+	Region    string    `json:"region"`
+	Branch    string    `json:"branch"`
+	Commit    string    `json:"commit"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func readLocalReviewApps() ([]LocalReviewApp, error) {
+	content, err := os.ReadFile(localReviewAppsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the local review apps")
+	}
+
+	var reviewApps []LocalReviewApp
+	err = json.Unmarshal(content, &reviewApps)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the local review apps")
+	}
+	return reviewApps, nil
+}
+
+func writeLocalReviewApps(reviewApps []LocalReviewApp) error {
+	content, err := json.MarshalIndent(reviewApps, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the local review apps")
+	}
+	err = os.WriteFile(localReviewAppsFile, content, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the local review apps")
+	}
+	return nil
+}
+
+func trackLocalReviewApp(reviewApp LocalReviewApp) error {
+	reviewApps, err := readLocalReviewApps()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	return writeLocalReviewApps(append(reviewApps, reviewApp))
+}
+
+// findLocalReviewApp returns the review app named name in the current region
+func findLocalReviewApp(name string) (LocalReviewApp, bool, error) {
+	reviewApps, err := readLocalReviewApps()
+	if err != nil {
+		return LocalReviewApp{}, false, errgo.Mask(err)
+	}
+	for _, reviewApp := range reviewApps {
+		if reviewApp.Name == name && reviewApp.Region == config.C.ScalingoRegion {
+			return reviewApp, true, nil
+		}
+	}
+	return LocalReviewApp{}, false, nil
+}
+
+func untrackLocalReviewApp(name string) error {
+	reviewApps, err := readLocalReviewApps()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	kept := []LocalReviewApp{}
+	for _, reviewApp := range reviewApps {
+		if reviewApp.Name == name && reviewApp.Region == config.C.ScalingoRegion {
+			continue
+		}
+		kept = append(kept, reviewApp)
+	}
+	return writeLocalReviewApps(kept)
+}
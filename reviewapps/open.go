@@ -0,0 +1,42 @@
+package reviewapps
+
+import (
+	"context"
+
+	"github.com/pkg/browser"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+)
+
+// Open opens in the browser the URL of the review app of a pull request
+func Open(ctx context.Context, parentApp string, pullRequest int) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	reviewApps, err := c.SCMRepoLinkReviewApps(ctx, parentApp)
+	if err != nil {
+		return errgo.Notef(err, "fail to get review apps for this app")
+	}
+
+	for _, ra := range reviewApps {
+		if ra.PullRequest == nil || ra.PullRequest.Number != pullRequest {
+			continue
+		}
+
+		app, err := c.AppsShow(ctx, ra.AppID)
+		if err != nil {
+			return errgo.Notef(err, "fail to get app from review app")
+		}
+		io.Statusf("Opening %s\n", app.URL)
+		err = browser.OpenURL(app.URL)
+		if err != nil {
+			return errgo.Notef(err, "fail to open review app in browser")
+		}
+		return nil
+	}
+	return errgo.Newf("no review app of '%s' for the pull request #%d", parentApp, pullRequest)
+}
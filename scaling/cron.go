@@ -0,0 +1,128 @@
+package scaling
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+)
+
+// cronExpression is a standard cron expression with 5 fields: minute, hour,
+// day of month, month and day of week. Each field accepts '*', values, ranges
+// (1-5), lists (1,3,5) and steps (*/15, 8-18/2).
+type cronExpression struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	// The day matches if the day of month or the day of week matches when
+	// both are restricted, as in crontab
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is also Sunday
+	{name: "day of week", min: 0, max: 7},
+}
+
+func parseCron(expression string) (cronExpression, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return cronExpression{}, errgo.Newf("invalid cron expression '%s', 5 fields are expected: minute hour day-of-month month day-of-week", expression)
+	}
+
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		var err error
+		values[i], err = parseCronField(field, cronFields[i])
+		if err != nil {
+			return cronExpression{}, errgo.Notef(err, "invalid cron expression '%s'", expression)
+		}
+	}
+	if values[4][7] {
+		values[4][0] = true
+	}
+
+	return cronExpression{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(value string, field cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(value, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, errgo.Newf("invalid step in the %s field '%s'", field.name, value)
+			}
+			part = part[:i]
+		}
+
+		start, end := field.min, field.max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			start, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, errgo.Newf("invalid %s field '%s'", field.name, value)
+			}
+			end = start
+			if len(bounds) == 2 {
+				end, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return nil, errgo.Newf("invalid %s field '%s'", field.name, value)
+				}
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return nil, errgo.Newf("the %s field '%s' is out of range %d-%d", field.name, value, field.min, field.max)
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// Match returns true if the expression matches the minute of t
+func (e cronExpression) Match(t time.Time) bool {
+	if !e.minutes[t.Minute()] || !e.hours[t.Hour()] || !e.months[int(t.Month())] {
+		return false
+	}
+
+	dayOfMonth := e.daysOfMonth[t.Day()]
+	dayOfWeek := e.daysOfWeek[int(t.Weekday())]
+	switch {
+	case e.anyDayOfMonth && e.anyDayOfWeek:
+		return true
+	case e.anyDayOfMonth:
+		return dayOfWeek
+	case e.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
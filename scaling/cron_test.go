@@ -0,0 +1,91 @@
+package scaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	// 2024-01-01 is a Monday
+	monday8am := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	sunday8pm := time.Date(2024, 1, 7, 20, 0, 0, 0, time.UTC)
+	fifteenth := time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		expression    string
+		matching      []time.Time
+		notMatching   []time.Time
+		expectedError string
+	}{
+		"every minute": {
+			expression: "* * * * *",
+			matching:   []time.Time{monday8am, sunday8pm, fifteenth},
+		},
+		"week days at 8": {
+			expression:  "0 8 * * 1-5",
+			matching:    []time.Time{monday8am},
+			notMatching: []time.Time{sunday8pm, fifteenth},
+		},
+		"sunday as 7": {
+			expression:  "0 20 * * 7",
+			matching:    []time.Time{sunday8pm},
+			notMatching: []time.Time{monday8am},
+		},
+		"steps and lists": {
+			expression:  "*/30 8,20 * * *",
+			matching:    []time.Time{monday8am, sunday8pm, fifteenth},
+			notMatching: []time.Time{monday8am.Add(15 * time.Minute)},
+		},
+		"day of month or day of week": {
+			expression:  "30 8 15 * 0",
+			matching:    []time.Time{fifteenth},
+			notMatching: []time.Time{monday8am},
+		},
+		"with a missing field": {
+			expression:    "0 8 * *",
+			expectedError: "5 fields are expected",
+		},
+		"with an out of range value": {
+			expression:    "0 24 * * *",
+			expectedError: "the hour field '24' is out of range 0-23",
+		},
+		"with an invalid step": {
+			expression:    "*/0 * * * *",
+			expectedError: "invalid step in the minute field",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expression, err := parseCron(test.expression)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			for _, m := range test.matching {
+				assert.True(t, expression.Match(m), "%s should match %v", test.expression, m)
+			}
+			for _, m := range test.notMatching {
+				assert.False(t, expression.Match(m), "%s should not match %v", test.expression, m)
+			}
+		})
+	}
+}
+
+func TestSchedule_CrontabLine(t *testing.T) {
+	schedule := Schedule{App: "my-app", Region: "osc-fr1", Cron: "0 20 * * *", Preset: "night"}
+
+	assert.Equal(t,
+		"0 20 * * * /usr/local/bin/scalingo --region osc-fr1 --app my-app scale --preset night",
+		schedule.CrontabLine("/usr/local/bin/scalingo"),
+	)
+	assert.Equal(t,
+		[]string{"scalingo", "--region", "osc-fr1", "--app", "my-app", "scale", "--preset", "night", "--dry-run"},
+		schedule.ScaleArgs("scalingo", true),
+	)
+}
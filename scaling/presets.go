@@ -0,0 +1,183 @@
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+var (
+	presetsFile = filepath.Join(config.C.ConfigDir, "scale_presets.json")
+)
+
+// Preset is a named formation of an application. The presets are kept
+// locally, the Scalingo API has no such concept.
+type Preset struct {
+	Name       string                   `json:"name"`
+	App        string                   `json:"app"`
+	Region     string                   `json:"region"`
+	Containers []scalingo.ContainerType `json:"containers"`
+	SavedAt    time.Time                `json:"saved_at"`
+}
+
+// Types returns the scaling instructions of the preset: <type>:<amount>:<size>
+func (p Preset) Types() []string {
+	types := make([]string, 0, len(p.Containers))
+	for _, container := range p.Containers {
+		types = append(types, fmt.Sprintf("%s:%d:%s", container.Name, container.Amount, container.Size))
+	}
+	return types
+}
+
+func readPresets() ([]Preset, error) {
+	content, err := os.ReadFile(presetsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the scale presets")
+	}
+
+	var presets []Preset
+	err = json.Unmarshal(content, &presets)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the scale presets")
+	}
+	return presets, nil
+}
+
+func writePresets(presets []Preset) error {
+	content, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the scale presets")
+	}
+	err = os.WriteFile(presetsFile, content, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the scale presets")
+	}
+	return nil
+}
+
+// FindPreset returns the preset named name of the application in region
+func FindPreset(region, app, name string) (Preset, error) {
+	presets, err := readPresets()
+	if err != nil {
+		return Preset{}, errgo.Mask(err)
+	}
+	for _, preset := range presets {
+		if preset.Region == region && preset.App == app && preset.Name == name {
+			return preset, nil
+		}
+	}
+	return Preset{}, errgo.Newf("no scale preset named %s for %s, the presets are listed by 'scale-presets'", name, app)
+}
+
+// PresetSave saves the current formation of the application as the preset
+// name, replacing the preset with the same name
+func PresetSave(ctx context.Context, app, name string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	containerTypes, err := c.AppsContainerTypes(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the formation of the application")
+	}
+
+	preset := Preset{
+		Name:    name,
+		App:     app,
+		Region:  config.C.ScalingoRegion,
+		SavedAt: time.Now(),
+	}
+	for _, ct := range containerTypes {
+		preset.Containers = append(preset.Containers, scalingo.ContainerType{Name: ct.Name, Amount: ct.Amount, Size: ct.Size})
+	}
+
+	presets, err := readPresets()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	presets = append(removePreset(presets, preset.Region, app, name), preset)
+	err = writePresets(presets)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	io.Statusf("The formation of %s has been saved as the preset %s:\n", app, io.Bold(name))
+	for _, container := range preset.Containers {
+		fmt.Println(io.Indent(fmt.Sprintf("%s: %d - %s", container.Name, container.Amount, container.Size), 7))
+	}
+	return nil
+}
+
+// PresetDelete deletes the preset name of the application
+func PresetDelete(app, name string) error {
+	_, err := FindPreset(config.C.ScalingoRegion, app, name)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	presets, err := readPresets()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	err = writePresets(removePreset(presets, config.C.ScalingoRegion, app, name))
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The preset %s of %s has been deleted\n", io.Bold(name), app)
+	return nil
+}
+
+// Presets lists the presets of the application
+func Presets(app string) error {
+	presets, err := readPresets()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Name", "Formation", "Saved At"})
+	found := false
+	for _, preset := range presets {
+		if preset.Region != config.C.ScalingoRegion || preset.App != app {
+			continue
+		}
+		found = true
+		formation := ""
+		for i, container := range preset.Containers {
+			if i > 0 {
+				formation += "\n"
+			}
+			formation += fmt.Sprintf("%s: %d - %s", container.Name, container.Amount, container.Size)
+		}
+		t.Append([]string{preset.Name, formation, preset.SavedAt.Format(time.RFC1123)})
+	}
+	if !found {
+		io.Statusf("%s has no scale preset, save one with 'scale-preset save <name>'\n", app)
+		return nil
+	}
+	t.Render()
+	return nil
+}
+
+func removePreset(presets []Preset, region, app, name string) []Preset {
+	kept := []Preset{}
+	for _, preset := range presets {
+		if preset.Region == region && preset.App == app && preset.Name == name {
+			continue
+		}
+		kept = append(kept, preset)
+	}
+	return kept
+}
@@ -0,0 +1,105 @@
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+)
+
+type SchedulerOpts struct {
+	// DryRun only prints the scaling parameters which would be sent when a
+	// schedule is due
+	DryRun bool
+}
+
+// RunScheduler applies the schedules when they are due, until ctx is done.
+// The schedules are read every minute, the changes are taken into account
+// without restarting the scheduler. Each preset is applied by a separate
+// process of the CLI running 'scale --preset'.
+func RunScheduler(ctx context.Context, opts SchedulerOpts) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return errgo.Notef(err, "fail to find the CLI executable")
+	}
+
+	schedules, err := readSchedules()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("Scheduler started with %d scale schedules\n", len(schedules))
+
+	// The schedules are applied concurrently and without blocking the clock, a
+	// scaling operation lasting more than a minute must not make the scheduler
+	// miss the next schedules
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		next := time.Now().Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+
+		schedules, err := readSchedules()
+		if err != nil {
+			logScheduler(next, "Fail to read the schedules: %v", err)
+			continue
+		}
+		for _, schedule := range dueSchedules(schedules, next) {
+			wg.Add(1)
+			go func(schedule Schedule, t time.Time) {
+				defer wg.Done()
+				applySchedule(ctx, executable, schedule, t, opts.DryRun)
+			}(schedule, next)
+		}
+	}
+}
+
+// outputMutex prevents the outputs of the schedules applied concurrently from
+// being mixed
+var outputMutex sync.Mutex
+
+// dueSchedules returns the schedules matching the minute t
+func dueSchedules(schedules []Schedule, t time.Time) []Schedule {
+	due := []Schedule{}
+	for _, schedule := range schedules {
+		expression, err := parseCron(schedule.Cron)
+		if err != nil {
+			logScheduler(t, "Invalid schedule of %s: %v", schedule.App, err)
+			continue
+		}
+		if expression.Match(t) {
+			due = append(due, schedule)
+		}
+	}
+	return due
+}
+
+func applySchedule(ctx context.Context, executable string, schedule Schedule, t time.Time, dryRun bool) {
+	args := schedule.ScaleArgs(executable, dryRun)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	output, err := cmd.CombinedOutput()
+
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+	logScheduler(t, "Scaling %s (%s) with the preset %s", schedule.App, schedule.Region, schedule.Preset)
+	if len(output) > 0 {
+		fmt.Println(io.Indent(strings.TrimRight(string(output), "\n"), 7))
+	}
+	if err != nil {
+		logScheduler(t, "Fail to scale %s: %v", schedule.App, err)
+	}
+}
+
+func logScheduler(t time.Time, format string, args ...interface{}) {
+	io.Statusf("[%s] %s\n", t.Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
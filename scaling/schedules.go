@@ -0,0 +1,188 @@
+package scaling
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/fleet"
+	"github.com/Scalingo/cli/io"
+)
+
+var (
+	schedulesFile = filepath.Join(config.C.ConfigDir, "scale_schedules.json")
+)
+
+// Schedule applies a preset to an application at the times matching a cron
+// expression. The schedules are evaluated by the 'scheduler' command or by
+// crontab.
+type Schedule struct {
+	App    string `json:"app"`
+	Region string `json:"region"`
+	Cron   string `json:"cron"`
+	Preset string `json:"preset"`
+}
+
+// ScaleArgs returns the arguments of the CLI applying the preset
+func (s Schedule) ScaleArgs(executable string, dryRun bool) []string {
+	args := []string{executable, "scale", "--preset", s.Preset}
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+	return fleet.CommandArgs(args, s.Region, s.App)
+}
+
+// CrontabLine returns the line of crontab running the schedule
+func (s Schedule) CrontabLine(executable string) string {
+	return s.Cron + " " + strings.Join(s.ScaleArgs(executable, false), " ")
+}
+
+type ListSchedulesOpts struct {
+	App string
+	// All lists the schedules of all the applications of all the regions
+	All bool
+	// Crontab prints the schedules as crontab lines
+	Crontab bool
+}
+
+func readSchedules() ([]Schedule, error) {
+	content, err := os.ReadFile(schedulesFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the scale schedules")
+	}
+
+	var schedules []Schedule
+	err = json.Unmarshal(content, &schedules)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the scale schedules")
+	}
+	return schedules, nil
+}
+
+func writeSchedules(schedules []Schedule) error {
+	content, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the scale schedules")
+	}
+	err = os.WriteFile(schedulesFile, content, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the scale schedules")
+	}
+	return nil
+}
+
+// ScheduleAdd schedules the application of the preset to the application at
+// the times matching cron
+func ScheduleAdd(app, cron, preset string) error {
+	_, err := parseCron(cron)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	_, err = FindPreset(config.C.ScalingoRegion, app, preset)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	schedules, err := readSchedules()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	schedule := Schedule{App: app, Region: config.C.ScalingoRegion, Cron: cron, Preset: preset}
+	err = writeSchedules(append(schedules, schedule))
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	io.Statusf("%s will be scaled with the preset %s at '%s'\n", app, io.Bold(preset), cron)
+	io.Info("The schedules are applied by 'scalingo scheduler' or by crontab with the lines of 'scalingo scale-schedules --crontab'")
+	return nil
+}
+
+// ScheduleRemove removes the schedule of the application numbered number in
+// the list of 'scale-schedules'
+func ScheduleRemove(app string, number int) error {
+	schedules, err := readSchedules()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	kept := []Schedule{}
+	index := 0
+	var removed *Schedule
+	for i, schedule := range schedules {
+		if schedule.Region == config.C.ScalingoRegion && schedule.App == app {
+			index++
+			if index == number {
+				removed = &schedules[i]
+				continue
+			}
+		}
+		kept = append(kept, schedule)
+	}
+	if removed == nil {
+		return errgo.Newf("%s has no schedule number %d, the schedules are listed by 'scale-schedules'", app, number)
+	}
+
+	err = writeSchedules(kept)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The schedule '%s' of the preset %s has been removed\n", removed.Cron, io.Bold(removed.Preset))
+	return nil
+}
+
+// Schedules lists the schedules of the application, or of all the applications
+func Schedules(opts ListSchedulesOpts) error {
+	schedules, err := readSchedules()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	selected := []Schedule{}
+	for _, schedule := range schedules {
+		if opts.All || (schedule.Region == config.C.ScalingoRegion && schedule.App == opts.App) {
+			selected = append(selected, schedule)
+		}
+	}
+
+	if opts.Crontab {
+		executable, err := os.Executable()
+		if err != nil {
+			return errgo.Notef(err, "fail to find the CLI executable")
+		}
+		for _, schedule := range selected {
+			fmt.Println(schedule.CrontabLine(executable))
+		}
+		return nil
+	}
+
+	if len(selected) == 0 {
+		io.Status("No scale schedule, add one with 'scale-schedule add <cron> <preset>'")
+		return nil
+	}
+	t := tablewriter.NewWriter(os.Stdout)
+	if opts.All {
+		t.SetHeader([]string{"App", "Region", "Cron", "Preset"})
+	} else {
+		t.SetHeader([]string{"#", "Cron", "Preset"})
+	}
+	for i, schedule := range selected {
+		if opts.All {
+			t.Append([]string{schedule.App, schedule.Region, schedule.Cron, schedule.Preset})
+		} else {
+			t.Append([]string{strconv.Itoa(i + 1), schedule.Cron, schedule.Preset})
+		}
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,189 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/config"
+)
+
+const (
+	// JSONFile defines the tasks in its "tasks" key, next to the other settings
+	// of the application
+	JSONFile = "scalingo.json"
+	// YAMLFile defines the tasks in its "tasks" key
+	YAMLFile = ".scalingo/tasks.yml"
+)
+
+// Task is a named one-off command defined in the repository
+type Task struct {
+	Name        string            `json:"-" yaml:"-"`
+	Description string            `json:"description" yaml:"description"`
+	Command     string            `json:"command" yaml:"command"`
+	Size        string            `json:"size" yaml:"size"`
+	Env         map[string]string `json:"env" yaml:"env"`
+	Files       []string          `json:"files" yaml:"files"`
+	// ConfirmApps are the patterns of the apps on which the task is only run
+	// after a confirmation (e.g. '*-production'), '*' for all the apps
+	ConfirmApps []string `json:"confirm_apps" yaml:"confirm_apps"`
+}
+
+type tasksFile struct {
+	Tasks map[string]Task `json:"tasks" yaml:"tasks"`
+}
+
+// Load returns the tasks defined in the tasks files of the current directory
+// or of its closest parent having one, sorted by name
+func Load() ([]Task, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get the current directory")
+	}
+	for {
+		tasks, found, err := loadDir(dir)
+		if err != nil {
+			return nil, errgo.Mask(err)
+		}
+		if found {
+			return tasks, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errgo.Newf("no task defined, add them to %s or %s", JSONFile, YAMLFile)
+		}
+		dir = parent
+	}
+}
+
+// loadDir reads the tasks files of dir, found is false if it has none
+func loadDir(dir string) ([]Task, bool, error) {
+	found := false
+	byName := map[string]Task{}
+	for _, name := range []string{JSONFile, YAMLFile} {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		content, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, false, errgo.Notef(err, "fail to read %s", filePath)
+		}
+		found = true
+
+		tasks, err := parse(name, content)
+		if err != nil {
+			return nil, false, errgo.Notef(err, "invalid tasks in %s", filePath)
+		}
+		for taskName, task := range tasks {
+			if _, ok := byName[taskName]; ok {
+				return nil, false, errgo.Newf("the task %s is defined in both %s and %s", taskName, JSONFile, YAMLFile)
+			}
+			task.Name = taskName
+			task.Files = filesPaths(filepath.Dir(filePath), task.Files)
+			byName[taskName] = task
+		}
+	}
+
+	tasks := make([]Task, 0, len(byName))
+	for _, task := range byName {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Name < tasks[j].Name
+	})
+	return tasks, found, nil
+}
+
+func parse(name string, content []byte) (map[string]Task, error) {
+	var file tasksFile
+	var err error
+	if name == JSONFile {
+		err = json.Unmarshal(content, &file)
+	} else {
+		err = yaml.Unmarshal(content, &file)
+	}
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+	for taskName, task := range file.Tasks {
+		if strings.TrimSpace(task.Command) == "" {
+			return nil, errgo.Newf("the task %s has no command", taskName)
+		}
+	}
+	return file.Tasks, nil
+}
+
+// filesPaths returns the paths of the files to upload, relative to the
+// directory of the tasks file
+func filesPaths(dir string, files []string) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		if filepath.IsAbs(file) {
+			paths = append(paths, file)
+		} else {
+			paths = append(paths, filepath.Join(dir, file))
+		}
+	}
+	return paths
+}
+
+// Find returns the task named name
+func Find(tasks []Task, name string) (Task, error) {
+	for _, task := range tasks {
+		if task.Name == name {
+			return task, nil
+		}
+	}
+	return Task{}, errgo.Newf("no task named %s, the list of the tasks is displayed by 'scalingo task --list'", name)
+}
+
+// NeedsConfirmation returns true if the task must be confirmed before being run
+// on app
+func (t Task) NeedsConfirmation(app string) bool {
+	for _, pattern := range t.ConfirmApps {
+		if matched, _ := path.Match(pattern, app); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RunOpts returns the options to run the task on app, args are appended to its
+// command
+func (t Task) RunOpts(app string, args []string) apps.RunOpts {
+	env := make([]string, 0, len(t.Env))
+	for name, value := range t.Env {
+		env = append(env, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(env)
+
+	return apps.RunOpts{
+		App:    app,
+		Cmd:    append([]string{t.Command}, args...),
+		Size:   t.Size,
+		CmdEnv: env,
+		Files:  t.Files,
+		Async:  true,
+		Record: config.C.RecordRuns,
+	}
+}
+
+// Display prints the tasks in a table
+func Display(tasks []Task) {
+	t := tablewriter.NewWriter(os.Stdout)
+	t.SetHeader([]string{"Name", "Command", "Size", "Description"})
+	for _, task := range tasks {
+		t.Append([]string{task.Name, task.Command, task.Size, task.Description})
+	}
+	t.Render()
+}
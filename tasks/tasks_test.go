@@ -0,0 +1,103 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDir(t *testing.T) {
+	tests := map[string]struct {
+		files         map[string]string
+		expectedTasks []string
+		expectedFound bool
+		expectedError string
+	}{
+		"without tasks file": {
+			files:         map[string]string{},
+			expectedTasks: []string{},
+		},
+		"with the JSON file": {
+			files: map[string]string{
+				JSONFile: `{"env": {"A": {"value": "1"}}, "tasks": {"migrate": {"command": "rake db:migrate"}}}`,
+			},
+			expectedTasks: []string{"migrate"},
+			expectedFound: true,
+		},
+		"with both files": {
+			files: map[string]string{
+				JSONFile: `{"tasks": {"migrate": {"command": "rake db:migrate"}}}`,
+				YAMLFile: "tasks:\n  seed:\n    command: rake db:seed\n  console:\n    command: rails console\n",
+			},
+			expectedTasks: []string{"console", "migrate", "seed"},
+			expectedFound: true,
+		},
+		"with a task defined twice": {
+			files: map[string]string{
+				JSONFile: `{"tasks": {"migrate": {"command": "rake db:migrate"}}}`,
+				YAMLFile: "tasks:\n  migrate:\n    command: rake db:migrate\n",
+			},
+			expectedError: "the task migrate is defined in both",
+		},
+		"with a task without command": {
+			files: map[string]string{
+				YAMLFile: "tasks:\n  migrate:\n    size: XL\n",
+			},
+			expectedError: "the task migrate has no command",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range test.files {
+				filePath := filepath.Join(dir, filepath.FromSlash(file))
+				require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+				require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+			}
+
+			tasks, found, err := loadDir(dir)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFound, found)
+			names := []string{}
+			for _, task := range tasks {
+				names = append(names, task.Name)
+			}
+			assert.Equal(t, test.expectedTasks, names)
+		})
+	}
+}
+
+func TestTask_RunOpts(t *testing.T) {
+	task := Task{
+		Name:    "migrate",
+		Command: "bundle exec rake db:migrate",
+		Size:    "XL",
+		Env:     map[string]string{"VERBOSE": "true", "LOG_LEVEL": "debug"},
+		Files:   []string{"/repo/db/seeds.csv"},
+	}
+
+	opts := task.RunOpts("my-app", []string{"VERSION=1"})
+	assert.Equal(t, "my-app", opts.App)
+	assert.Equal(t, []string{"bundle exec rake db:migrate", "VERSION=1"}, opts.Cmd)
+	assert.Equal(t, "XL", opts.Size)
+	assert.Equal(t, []string{"LOG_LEVEL=debug", "VERBOSE=true"}, opts.CmdEnv)
+	assert.Equal(t, []string{"/repo/db/seeds.csv"}, opts.Files)
+}
+
+func TestTask_NeedsConfirmation(t *testing.T) {
+	task := Task{ConfirmApps: []string{"*-production", "billing"}}
+
+	assert.True(t, task.NeedsConfirmation("my-app-production"))
+	assert.True(t, task.NeedsConfirmation("billing"))
+	assert.False(t, task.NeedsConfirmation("my-app-staging"))
+	assert.False(t, Task{}.NeedsConfirmation("my-app-production"))
+}
@@ -7,18 +7,14 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/events"
-	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func Events(ctx context.Context, paginationOpts scalingo.PaginationOpts) error {
+func Events(ctx context.Context, opts events.TimelineOpts) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
-	appEvents, pagination, err := c.UserEventsList(ctx, paginationOpts)
-	if err != nil {
-		return errgo.Mask(err)
-	}
 
-	return events.DisplayTimeline(appEvents, pagination, events.DisplayTimelineOpts{DisplayAppName: true})
+	opts.DisplayAppName = true
+	return events.Timeline(ctx, c.UserEventsList, opts)
 }
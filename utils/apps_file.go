@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+)
+
+// ReadAppsFile reads a list of application names, one per line. Empty lines
+// and lines starting with # are ignored. The list is read from the standard
+// input if path is "-".
+func ReadAppsFile(path string) ([]string, error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		fd, err := os.Open(path)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to open the list of applications")
+		}
+		defer fd.Close()
+		reader = fd
+	}
+
+	apps := []string{}
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		app := strings.TrimSpace(scanner.Text())
+		if app == "" || strings.HasPrefix(app, "#") || seen[app] {
+			continue
+		}
+		seen[app] = true
+		apps = append(apps, app)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errgo.Notef(err, "fail to read the list of applications")
+	}
+	if len(apps) == 0 {
+		return nil, errgo.Newf("no application listed in %s", path)
+	}
+	return apps, nil
+}
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// ShellCommand returns the command executing the given command line with the
+// shell of the operating system, used to run the hooks configured by the user
+func ShellCommand(commandLine string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", commandLine)
+	}
+	return exec.Command("sh", "-c", commandLine)
+}
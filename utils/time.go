@@ -1,5 +1,29 @@
 package utils
 
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+)
+
 const (
 	TimeFormat = "2006/01/02 15:04:05"
 )
+
+// ParseDuration parses a duration given on the command line. On top of the
+// units accepted by time.ParseDuration, a number of days can be given: 30d
+func ParseDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		amount, err := strconv.Atoi(days)
+		if err == nil && amount >= 0 {
+			return time.Duration(amount) * 24 * time.Hour, nil
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, errgo.Newf("invalid duration '%s', use a number of days like 30d or a duration like 12h", value)
+	}
+	return duration, nil
+}